@@ -0,0 +1,145 @@
+/*
+* File Name:	aiqq.go
+* Description:  腾讯AI开放平台(api.ai.qq.com)的签名和调用
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//AIHost 腾讯AI开放平台的服务地址
+const AIHost = "api.ai.qq.com"
+
+//AIClient 腾讯AI开放平台的客户端. 该平台的接口与优图相同, 但请求为表单格式,
+//签名是对排序后的参数做MD5, 凭证为app_id和app_key
+type AIClient struct {
+	appID  string
+	appKey string
+	y      *Youtu //连接、时间和随机数来源等设置
+}
+
+//NewAIClient 新建AI开放平台的客户端, 默认以https访问AIHost.
+//opts与Init相同, 其中WithHost, WithProxy, WithTransportOptions, WithClock等对AIClient生效
+func NewAIClient(appID, appKey string, opts ...Option) *AIClient {
+	opts = append([]Option{WithHost(AIHost), WithScheme("https")}, opts...)
+	return &AIClient{appID: appID, appKey: appKey, y: Init(AppSign{}, opts...)}
+}
+
+//aiResponse AI开放平台的响应
+type aiResponse struct {
+	Ret  int             `json:"ret"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+//Call 以签名后的params调用path指定的接口, 如"/fcgi-bin/face/face_detectface",
+//返回的data解码到data. ret非0时返回*APIError
+func (c *AIClient) Call(ctx context.Context, path string, params url.Values, data interface{}) (err error) {
+	if c.y.transportErr != nil {
+		return c.y.transportErr
+	}
+	form := url.Values{}
+	for k, v := range params {
+		form[k] = v
+	}
+	form.Set("app_id", c.appID)
+	form.Set("time_stamp", strconv.FormatInt(c.y.signTime().Unix(), 10))
+	form.Set("nonce_str", strconv.FormatInt(int64(c.y.nonce()), 36))
+	form.Set("sign", aiSign(form, c.appKey))
+	req, err := http.NewRequest("POST", c.y.baseURL()+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", c.y.userAgent)
+	timeout := timeoutNormal.duration()
+	if c.y.timeout > 0 {
+		timeout = c.y.timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	resp, err := c.y.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		err = &StatusError{Interface: path, StatusCode: resp.StatusCode, Body: strings.ToValidUTF8(string(snippet), "")}
+		return
+	}
+	var body io.Reader = resp.Body
+	if max := c.y.maxResponseBytes; max > 0 {
+		if resp.ContentLength > max {
+			err = &ResponseTooLargeError{Interface: path, Limit: max}
+			return
+		}
+		body = &limitedReader{r: body, remaining: max, err: &ResponseTooLargeError{Interface: path, Limit: max}}
+	}
+	var ar aiResponse
+	if err = json.NewDecoder(body).Decode(&ar); err != nil {
+		return
+	}
+	if err = checkCode(path, ar.Ret, ar.Msg); err != nil || data == nil || len(ar.Data) == 0 {
+		return
+	}
+	return json.Unmarshal(ar.Data, data)
+}
+
+//aiSign 计算AI开放平台的签名: 参数按名称排序并URL编码后拼接, 末尾加上app_key, 取MD5的大写十六进制
+func aiSign(params url.Values, appKey string) string {
+	p := url.Values{}
+	for k, v := range params {
+		if k != "sign" && len(v) > 0 && v[0] != "" {
+			p[k] = v[:1]
+		}
+	}
+	sum := md5.Sum([]byte(p.Encode() + "&app_key=" + url.QueryEscape(appKey)))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+//AIFace AI开放平台检测出的人脸
+type AIFace struct {
+	FaceID     string `json:"face_id"`    //人脸标识
+	X          int32  `json:"x"`          //人脸框左上角x
+	Y          int32  `json:"y"`          //人脸框左上角y
+	Width      int32  `json:"width"`      //人脸框宽度
+	Height     int32  `json:"height"`     //人脸框高度
+	Gender     int32  `json:"gender"`     //性别 [0/(female)~100(male)]
+	Age        int32  `json:"age"`        //年龄 [0~100]
+	Expression int32  `json:"expression"` //微笑[0(normal)~50(smile)~100(laugh)]
+	Beauty     int32  `json:"beauty"`     //魅力[0~100]
+	Glass      int32  `json:"glass"`      //是否有眼镜 [0,1]
+	Pitch      int32  `json:"pitch"`      //上下偏移[-30,30]
+	Yaw        int32  `json:"yaw"`        //左右偏移[-30,30]
+	Roll       int32  `json:"roll"`       //平面旋转[-180,180]
+}
+
+//AIDetectFaceRsp AI开放平台人脸检测的返回
+type AIDetectFaceRsp struct {
+	ImageWidth  int32    `json:"image_width"`  //请求图片的宽度
+	ImageHeight int32    `json:"image_height"` //请求图片的高度
+	FaceList    []AIFace `json:"face_list"`    //检测出的人脸
+}
+
+//DetectFace 检测图片中的人脸, 同优图的DetectFace
+func (c *AIClient) DetectFace(ctx context.Context, imageData string, mode DetectMode) (rsp AIDetectFaceRsp, err error) {
+	params := url.Values{}
+	params.Set("image", imageData)
+	params.Set("mode", strconv.Itoa(int(mode)))
+	err = c.Call(ctx, "/fcgi-bin/face/face_detectface", params, &rsp)
+	return
+}
@@ -0,0 +1,74 @@
+/*
+* File Name:	aiqq_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAISign(t *testing.T) {
+	//AI开放平台文档中的示例
+	params := url.Values{}
+	params.Set("app_id", "10000")
+	params.Set("time_stamp", "1493449657")
+	params.Set("nonce_str", "20e3408a79")
+	params.Set("key1", "腾讯AI开放平台")
+	params.Set("key2", "示例仅供参考")
+	params.Set("sign", "ignored")
+	if got := aiSign(params, "a95eceb1ac8c24ee28b70f7dbba912bf"); got != "BE918C28827E0783D1E5F8E6D7C37A61" {
+		t.Errorf("aiSign: %s\n", got)
+	}
+}
+
+func TestAIClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.URL.Path != "/fcgi-bin/face/face_detectface" || r.PostForm.Get("sign") != aiSign(r.PostForm, "app_key") {
+			w.Write([]byte(`{"ret":16388,"msg":"app_key or sign invalid"}`))
+			return
+		}
+		if r.PostForm.Get("app_id") != "10000" || r.PostForm.Get("time_stamp") != "1500000000" || r.PostForm.Get("image") != "QUJD" {
+			w.Write([]byte(`{"ret":16385,"msg":"param invalid"}`))
+			return
+		}
+		w.Write([]byte(`{"ret":0,"msg":"ok","data":{"image_width":640,"image_height":480,"face_list":[{"face_id":"1","x":10,"width":100,"glass":1}]}}`))
+	}))
+	defer srv.Close()
+	c := NewAIClient("10000", "app_key", WithHost(strings.TrimPrefix(srv.URL, "https://")),
+		WithHTTPClient(srv.Client()), WithClock(fixedClock(time.Unix(1500000000, 0))))
+	c.y.SetRand(rand.New(rand.NewSource(1)))
+	rsp, err := c.DetectFace(context.Background(), "QUJD", DetectModeNormal)
+	if err != nil || rsp.ImageWidth != 640 || len(rsp.FaceList) != 1 || rsp.FaceList[0].Glass != 1 {
+		t.Errorf("DetectFace: %+v, %v\n", rsp, err)
+	}
+
+	c.appKey = "wrong"
+	_, err = c.DetectFace(context.Background(), "QUJD", DetectModeNormal)
+	if e, ok := err.(*APIError); !ok || e.Code != 16388 {
+		t.Errorf("DetectFace with wrong key: %v\n", err)
+	}
+
+	//WithMaxResponseBytes(0)不限制响应体, 超过上限时返回*ResponseTooLargeError
+	c.appKey = "app_key"
+	c.y.maxResponseBytes = 0
+	if _, err = c.DetectFace(context.Background(), "QUJD", DetectModeNormal); err != nil {
+		t.Errorf("DetectFace without response limit: %v\n", err)
+	}
+	c.y.maxResponseBytes = 16
+	_, err = c.DetectFace(context.Background(), "QUJD", DetectModeNormal)
+	if e, ok := err.(*ResponseTooLargeError); !ok || e.Limit != 16 {
+		t.Errorf("DetectFace over response limit: %v\n", err)
+	}
+}
@@ -0,0 +1,219 @@
+/*
+* File Name:	attribute.go
+* Description:  人脸属性归一化
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import "fmt"
+
+//GenderKind 性别
+type GenderKind int
+
+const (
+	//GenderFemale 女性
+	GenderFemale GenderKind = iota
+	//GenderMale 男性
+	GenderMale
+)
+
+var genderNames = []string{"female", "male"}
+
+func (g GenderKind) String() string {
+	return enumString(genderNames, int(g))
+}
+
+//MarshalText 编码为female/male
+func (g GenderKind) MarshalText() ([]byte, error) {
+	return enumMarshal(genderNames, int(g))
+}
+
+//UnmarshalText 从female/male解码
+func (g *GenderKind) UnmarshalText(text []byte) error {
+	return enumUnmarshal(genderNames, (*int)(g), text)
+}
+
+//Gender 归一化的性别, Probability为判断为该性别的概率[0.5, 1]
+type Gender struct {
+	Kind        GenderKind `json:"kind"`
+	Probability float32    `json:"probability"`
+}
+
+//NormalizeGender 将性别[0(female)~100(male)]归一化
+func NormalizeGender(gender int32) Gender {
+	gender = clamp(gender, 0, 100)
+	if gender >= 50 {
+		return Gender{Kind: GenderMale, Probability: float32(gender) / 100}
+	}
+	return Gender{Kind: GenderFemale, Probability: float32(100-gender) / 100}
+}
+
+//AgeRange 年龄区间[Min, Max], 按10岁划分
+type AgeRange struct {
+	Min int32 `json:"min"`
+	Max int32 `json:"max"`
+}
+
+func (a AgeRange) String() string {
+	return fmt.Sprintf("%d-%d", a.Min, a.Max)
+}
+
+//Contains 年龄是否在区间内
+func (a AgeRange) Contains(age int32) bool {
+	return age >= a.Min && age <= a.Max
+}
+
+//NormalizeAge 将年龄[0~100]归入所在的10岁区间, 最高的区间为90-100
+func NormalizeAge(age int32) AgeRange {
+	min := clamp(age, 0, 99) / 10 * 10
+	if min == 90 {
+		return AgeRange{Min: 90, Max: 100}
+	}
+	return AgeRange{Min: min, Max: min + 9}
+}
+
+//ExpressionLevel 表情程度
+type ExpressionLevel int
+
+const (
+	//ExpressionNormal 正常
+	ExpressionNormal ExpressionLevel = iota
+	//ExpressionSmile 微笑
+	ExpressionSmile
+	//ExpressionLaugh 大笑
+	ExpressionLaugh
+)
+
+var expressionNames = []string{"normal", "smile", "laugh"}
+
+func (e ExpressionLevel) String() string {
+	return enumString(expressionNames, int(e))
+}
+
+//MarshalText 编码为normal/smile/laugh
+func (e ExpressionLevel) MarshalText() ([]byte, error) {
+	return enumMarshal(expressionNames, int(e))
+}
+
+//UnmarshalText 从normal/smile/laugh解码
+func (e *ExpressionLevel) UnmarshalText(text []byte) error {
+	return enumUnmarshal(expressionNames, (*int)(e), text)
+}
+
+//NormalizeExpression 将表情[0(normal)~50(smile)~100(laugh)]归入最接近的程度
+func NormalizeExpression(expression int32) ExpressionLevel {
+	switch expression = clamp(expression, 0, 100); {
+	case expression < 25:
+		return ExpressionNormal
+	case expression < 75:
+		return ExpressionSmile
+	default:
+		return ExpressionLaugh
+	}
+}
+
+//PoseQuality 姿态质量, 越接近正脸质量越好
+type PoseQuality int
+
+const (
+	//PoseGood 接近正脸, 适合注册和比对
+	PoseGood PoseQuality = iota
+	//PoseFair 有一定偏转, 可以用于识别
+	PoseFair
+	//PosePoor 偏转过大, 结果不可靠
+	PosePoor
+)
+
+var poseNames = []string{"good", "fair", "poor"}
+
+func (p PoseQuality) String() string {
+	return enumString(poseNames, int(p))
+}
+
+//MarshalText 编码为good/fair/poor
+func (p PoseQuality) MarshalText() ([]byte, error) {
+	return enumMarshal(poseNames, int(p))
+}
+
+//UnmarshalText 从good/fair/poor解码
+func (p *PoseQuality) UnmarshalText(text []byte) error {
+	return enumUnmarshal(poseNames, (*int)(p), text)
+}
+
+//NormalizePose 根据上下偏移pitch, 左右偏移yaw和平面旋转roll评估姿态质量
+func NormalizePose(pitch, yaw, roll int32) PoseQuality {
+	p, y, r := abs(pitch), abs(yaw), abs(roll)
+	switch {
+	case p <= 10 && y <= 10 && r <= 15:
+		return PoseGood
+	case p <= 20 && y <= 20 && r <= 30:
+		return PoseFair
+	default:
+		return PosePoor
+	}
+}
+
+//NormalizedFace 归一化的人脸属性, 便于下游的数据模型使用
+type NormalizedFace struct {
+	FaceID     string          `json:"face_id"`
+	Gender     Gender          `json:"gender"`
+	Age        AgeRange        `json:"age"`
+	Expression ExpressionLevel `json:"expression"`
+	Glass      bool            `json:"glass"`
+	Pose       PoseQuality     `json:"pose"`
+}
+
+//Normalize 归一化人脸属性
+func (f Face) Normalize() NormalizedFace {
+	return NormalizedFace{
+		FaceID:     f.FaceID,
+		Gender:     NormalizeGender(f.Gender),
+		Age:        NormalizeAge(f.Age),
+		Expression: NormalizeExpression(f.Expression),
+		Glass:      f.Glass,
+		Pose:       NormalizePose(f.Pitch, f.Yaw, f.Roll),
+	}
+}
+
+func enumString(names []string, v int) string {
+	if v < 0 || v >= len(names) {
+		return fmt.Sprintf("unknown(%d)", v)
+	}
+	return names[v]
+}
+
+func enumMarshal(names []string, v int) ([]byte, error) {
+	if v < 0 || v >= len(names) {
+		return nil, fmt.Errorf("invalid value %d", v)
+	}
+	return []byte(names[v]), nil
+}
+
+func enumUnmarshal(names []string, v *int, text []byte) error {
+	for i, name := range names {
+		if name == string(text) {
+			*v = i
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value %q", text)
+}
+
+func clamp(v, min, max int32) int32 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,71 @@
+/*
+* File Name:	attribute_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	f := Face{FaceID: "f", Gender: 20, Age: 34, Expression: 60, Glass: true, Pitch: 5, Yaw: -15, Roll: 3}
+	nf := f.Normalize()
+	want := NormalizedFace{
+		FaceID:     "f",
+		Gender:     Gender{Kind: GenderFemale, Probability: 0.8},
+		Age:        AgeRange{Min: 30, Max: 39},
+		Expression: ExpressionSmile,
+		Glass:      true,
+		Pose:       PoseFair,
+	}
+	if nf != want {
+		t.Errorf("Normalize: %#v, want %#v\n", nf, want)
+	}
+}
+
+func TestNormalizedFaceJSON(t *testing.T) {
+	nf := Face{FaceID: "f", Gender: 90, Age: 20, Expression: 90}.Normalize()
+	data, err := json.Marshal(nf)
+	if err != nil {
+		t.Errorf("Marshal failed: %s\n", err)
+		return
+	}
+	want := `{"face_id":"f","gender":{"kind":"male","probability":0.9},"age":{"min":20,"max":29},"expression":"laugh","glass":false,"pose":"good"}`
+	if string(data) != want {
+		t.Errorf("Marshal: %s, want %s\n", data, want)
+	}
+	var got NormalizedFace
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Errorf("Unmarshal failed: %s\n", err)
+		return
+	}
+	if got != nf {
+		t.Errorf("Unmarshal: %#v, want %#v\n", got, nf)
+	}
+}
+
+func TestNormalizeAge(t *testing.T) {
+	cases := []struct {
+		age  int32
+		want AgeRange
+	}{
+		{-1, AgeRange{0, 9}},
+		{0, AgeRange{0, 9}},
+		{9, AgeRange{0, 9}},
+		{10, AgeRange{10, 19}},
+		{89, AgeRange{80, 89}},
+		{90, AgeRange{90, 100}},
+		{100, AgeRange{90, 100}},
+		{120, AgeRange{90, 100}},
+	}
+	for _, c := range cases {
+		if got := NormalizeAge(c.age); got != c.want || !got.Contains(clamp(c.age, 0, 100)) {
+			t.Errorf("NormalizeAge(%d) = %v, want %v\n", c.age, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+* File Name:	audit.go
+* Description:  修改类接口的审计日志
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+//hashMinLen 审计日志中不小于该长度的字符串(图片数据)只记录其sha256
+const hashMinLen = 256
+
+//AuditRecord 审计日志中的一条记录, 对应一次修改类接口的调用
+type AuditRecord struct {
+	Time      time.Time       `json:"time"`               //调用时间
+	Interface string          `json:"interface"`          //接口名
+	Reason    string          `json:"reason,omitempty"`   //调用方提供的操作原因
+	Request   json.RawMessage `json:"request"`            //请求摘要, 图片数据替换为sha256
+	ErrorCode int             `json:"errorcode"`          //返回状态码
+	ErrorMsg  string          `json:"errormsg,omitempty"` //返回错误消息
+	Error     string          `json:"error,omitempty"`    //请求本身失败时的错误
+}
+
+//AuditLog 以JSONL格式追加写入的审计日志, 可以被多个goroutine共用
+type AuditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+	c  io.Closer
+}
+
+//NewAuditLog 新建写入w的审计日志
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{w: w}
+}
+
+//OpenAuditLog 以追加方式打开文件作为审计日志, 文件不存在时创建
+func OpenAuditLog(path string) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{w: f, c: f}, nil
+}
+
+//Write 写入一条记录
+func (al *AuditLog) Write(rec AuditRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	_, err = al.w.Write(line)
+	return err
+}
+
+//Flush 刷新w的缓冲(如*bufio.Writer)并同步到磁盘(如*os.File)
+func (al *AuditLog) Flush() error {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	return flushWriter(al.w)
+}
+
+//Close 关闭由OpenAuditLog打开的文件
+func (al *AuditLog) Close() error {
+	if al.c == nil {
+		return nil
+	}
+	return al.c.Close()
+}
+
+//SetAuditLog 设置审计日志, 每次修改类接口的调用都会写入一条记录. al为nil时关闭
+func (y *Youtu) SetAuditLog(al *AuditLog) {
+	y.audit = al
+}
+
+//WithReason 返回客户端副本, 其审计记录带上操作原因reason
+func (y *Youtu) WithReason(reason string) *Youtu {
+	c := *y
+	c.auditReason = reason
+	return &c
+}
+
+//auditCall 记录一次调用, 记录失败不影响调用结果
+func (y *Youtu) auditCall(ifname string, req, body []byte, callErr error) {
+	rec := AuditRecord{
+		Time:      y.now(),
+		Interface: ifname,
+		Reason:    y.auditReason,
+		Request:   hashImages(req),
+	}
+	if callErr != nil {
+		rec.Error = callErr.Error()
+	} else {
+		var status struct {
+			ErrorCode int    `json:"errorcode"`
+			ErrorMsg  string `json:"errormsg"`
+		}
+		json.Unmarshal(body, &status)
+		rec.ErrorCode, rec.ErrorMsg = status.ErrorCode, status.ErrorMsg
+	}
+	y.audit.Write(rec)
+}
+
+//hashImages 将JSON中的长字符串替换为其sha256, 解析失败时返回null
+func hashImages(data []byte) json.RawMessage {
+	out, err := rewriteJSONStrings(data, func(_, s string) interface{} {
+		if len(s) < hashMinLen {
+			return s
+		}
+		sum := sha256.Sum256([]byte(s))
+		return "sha256:" + hex.EncodeToString(sum[:])
+	})
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return out
+}
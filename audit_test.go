@@ -0,0 +1,64 @@
+/*
+* File Name:	audit_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAuditLog(t *testing.T) {
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/delperson") {
+			fmt.Fprint(w, `{"errorcode":-1000,"errormsg":"ERROR_PERSON_NOT_EXISTED"}`)
+			return
+		}
+		fmt.Fprint(w, `{"errorcode":0}`)
+	})
+	defer srv.Close()
+	var buf bytes.Buffer
+	y.SetAuditLog(NewAuditLog(&buf))
+	image := strings.Repeat("A", 1000)
+	if _, err := y.WithReason("ticket-42").AddFace([]string{image}, "p", ""); err != nil {
+		t.Errorf("AddFace failed: %s\n", err)
+	}
+	if _, err := y.GetInfo("p"); err != nil {
+		t.Errorf("GetInfo failed: %s\n", err)
+	}
+	if _, err := y.DelPerson("p"); err != nil {
+		t.Errorf("DelPerson failed: %s\n", err)
+	}
+	if strings.Contains(buf.String(), image) {
+		t.Errorf("audit log contains image data\n")
+	}
+	var recs []AuditRecord
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var rec AuditRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			t.Errorf("Unmarshal %s failed: %s\n", sc.Text(), err)
+			return
+		}
+		recs = append(recs, rec)
+	}
+	if len(recs) != 2 {
+		t.Errorf("records: %v\n", recs)
+		return
+	}
+	if recs[0].Interface != "addface" || recs[0].Reason != "ticket-42" || !strings.Contains(string(recs[0].Request), "sha256:") {
+		t.Errorf("addface record: %#v\n", recs[0])
+	}
+	if recs[1].Interface != "delperson" || recs[1].Reason != "" || recs[1].ErrorCode != -1000 {
+		t.Errorf("delperson record: %#v\n", recs[1])
+	}
+}
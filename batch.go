@@ -0,0 +1,169 @@
+/*
+* File Name:	batch.go
+* Description:  批量操作
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+//DefaultConcurrency 批量操作的默认并发数
+const DefaultConcurrency = 4
+
+//ErrBudgetExceeded 批量操作超出运行时间预算错误
+var ErrBudgetExceeded = errors.New("batch budget exceeded")
+
+//BatchOptions 批量操作的选项
+type BatchOptions struct {
+	Concurrency int           //并发数, 不大于0时使用DefaultConcurrency
+	Budget      time.Duration //最长运行时间, 为0时不限制. 到达时不再开始新的项, 已开始的项会完成
+}
+
+//BatchSummary 批量操作的执行情况
+type BatchSummary struct {
+	Total     int           //总项数
+	Completed []int         //已执行的项的下标, 包括执行失败的项
+	Remaining []int         //因取消或超出预算而未执行的项的下标
+	Elapsed   time.Duration //运行时间
+	Err       error         //提前停止的原因: ctx.Err()或ErrBudgetExceeded, 全部执行时为nil
+}
+
+//runBatch 并发执行fn(0)...fn(n-1), ctx取消或超出预算时停止开始新的项, 返回时已开始的项全部完成
+func runBatch(ctx context.Context, n int, opts BatchOptions, fn func(i int)) (sum BatchSummary) {
+	start := time.Now()
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	bctx := ctx
+	if opts.Budget > 0 {
+		var cancel context.CancelFunc
+		bctx, cancel = context.WithTimeout(ctx, opts.Budget)
+		defer cancel()
+	}
+	done := make([]bool, n)
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				if bctx.Err() != nil {
+					continue
+				}
+				fn(i)
+				done[i] = true
+			}
+		}()
+	}
+dispatch:
+	for i := 0; i < n; i++ {
+		select {
+		case <-bctx.Done():
+			break dispatch
+		case idx <- i:
+		}
+	}
+	close(idx)
+	wg.Wait()
+
+	sum.Total = n
+	for i, ok := range done {
+		if ok {
+			sum.Completed = append(sum.Completed, i)
+		} else {
+			sum.Remaining = append(sum.Remaining, i)
+		}
+	}
+	if len(sum.Remaining) > 0 {
+		sum.Err = ctx.Err()
+		if sum.Err == nil {
+			sum.Err = ErrBudgetExceeded
+		}
+	}
+	sum.Elapsed = time.Since(start)
+	return
+}
+
+//PersonInfo 个体的名字和备注信息
+type PersonInfo struct {
+	PersonID   string
+	PersonName string //为空时不修改
+	Tag        string //为空时不修改
+}
+
+//SetInfoResult 批量设置中一行的结果
+type SetInfoResult struct {
+	PersonInfo
+	Rsp SetInfoRsp
+	Err error //请求失败, errorcode非0, 或者未执行(提前停止的原因)时不为nil
+}
+
+//BulkSetInfo 批量设置个体的名字和备注, 结果与infos一一对应.
+//ctx取消或超出opts.Budget时提前停止, 未执行的项记录在BatchSummary.Remaining中
+func (y *Youtu) BulkSetInfo(ctx context.Context, infos []PersonInfo, opts BatchOptions) ([]SetInfoResult, BatchSummary) {
+	results := make([]SetInfoResult, len(infos))
+	sum := runBatch(ctx, len(infos), opts, func(i int) {
+		info := infos[i]
+		r := SetInfoResult{PersonInfo: info}
+		r.Rsp, r.Err = y.SetInfoCtx(ctx, info.PersonID, info.PersonName, info.Tag)
+		if r.Err == nil {
+			r.Err = checkCode("setinfo", int(r.Rsp.ErrorCode), r.Rsp.ErrorMsg)
+		}
+		results[i] = r
+	})
+	for _, i := range sum.Remaining {
+		results[i] = SetInfoResult{PersonInfo: infos[i], Err: sum.Err}
+	}
+	return results, sum
+}
+
+//PersonInfosFromMap 将personID到名字和备注的映射转为按personID排序的列表
+func PersonInfosFromMap(m map[string]PersonInfo) []PersonInfo {
+	infos := make([]PersonInfo, 0, len(m))
+	for personID, info := range m {
+		info.PersonID = personID
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].PersonID < infos[j].PersonID
+	})
+	return infos
+}
+
+//ReadPersonInfoCSV 读取CSV格式的个体信息, 每行为person_id,person_name,tag,
+//tag列可省略. 首行为person_id开头的表头时跳过
+func ReadPersonInfoCSV(r io.Reader) (infos []PersonInfo, err error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return
+	}
+	for i, rec := range records {
+		if i == 0 && len(rec) > 0 && rec[0] == "person_id" {
+			continue
+		}
+		if len(rec) < 2 || len(rec) > 3 || rec[0] == "" {
+			return nil, fmt.Errorf("line %d: want person_id,person_name[,tag], got %q", i+1, rec)
+		}
+		info := PersonInfo{PersonID: rec[0], PersonName: rec[1]}
+		if len(rec) == 3 {
+			info.Tag = rec[2]
+		}
+		infos = append(infos, info)
+	}
+	return
+}
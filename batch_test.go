@@ -0,0 +1,107 @@
+/*
+* File Name:	batch_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReadPersonInfoCSV(t *testing.T) {
+	data := "person_id,person_name,tag\np1,张三,hr\np2,李四\n"
+	infos, err := ReadPersonInfoCSV(strings.NewReader(data))
+	if err != nil {
+		t.Errorf("ReadPersonInfoCSV failed: %s\n", err)
+		return
+	}
+	want := []PersonInfo{{"p1", "张三", "hr"}, {"p2", "李四", ""}}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("infos: %v, want %v\n", infos, want)
+	}
+	if _, err = ReadPersonInfoCSV(strings.NewReader("p1\n")); err == nil {
+		t.Errorf("ReadPersonInfoCSV should fail for short line\n")
+	}
+}
+
+func TestBulkSetInfo(t *testing.T) {
+	var mu sync.Mutex
+	got := make(map[string]string)
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		var req setInfoReq
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.PersonID == "missing" {
+			fmt.Fprint(w, `{"errorcode":-1000,"errormsg":"ERROR_PERSON_NOT_EXISTED"}`)
+			return
+		}
+		mu.Lock()
+		got[req.PersonID] = req.PersonName
+		mu.Unlock()
+		fmt.Fprintf(w, `{"person_id":%q,"errorcode":0}`, req.PersonID)
+	})
+	defer srv.Close()
+	infos := PersonInfosFromMap(map[string]PersonInfo{
+		"p1":      {PersonName: "n1"},
+		"p2":      {PersonName: "n2"},
+		"missing": {PersonName: "n3"},
+	})
+	results, sum := y.BulkSetInfo(context.Background(), infos, BatchOptions{Concurrency: 2})
+	if sum.Err != nil || len(sum.Completed) != 3 {
+		t.Errorf("summary: %#v\n", sum)
+	}
+	if len(results) != 3 {
+		t.Errorf("results: %v\n", results)
+		return
+	}
+	for _, r := range results {
+		if r.PersonID == "missing" {
+			if e, ok := r.Err.(*APIError); !ok || e.Code != -1000 {
+				t.Errorf("missing: %v\n", r.Err)
+			}
+			continue
+		}
+		if r.Err != nil || r.Rsp.PersonID != r.PersonID {
+			t.Errorf("result %s: %#v\n", r.PersonID, r)
+		}
+	}
+	if !reflect.DeepEqual(got, map[string]string{"p1": "n1", "p2": "n2"}) {
+		t.Errorf("server got: %v\n", got)
+	}
+}
+
+func TestRunBatchBudget(t *testing.T) {
+	var mu sync.Mutex
+	var ran []int
+	fn := func(i int) {
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		ran = append(ran, i)
+		mu.Unlock()
+	}
+	sum := runBatch(context.Background(), 100, BatchOptions{Concurrency: 2, Budget: 50 * time.Millisecond}, fn)
+	if sum.Err != ErrBudgetExceeded {
+		t.Errorf("Err: %v, want %v\n", sum.Err, ErrBudgetExceeded)
+	}
+	if len(sum.Completed) != len(ran) || len(sum.Completed)+len(sum.Remaining) != 100 || len(sum.Remaining) == 0 {
+		t.Errorf("completed %d, remaining %d, ran %d\n", len(sum.Completed), len(sum.Remaining), len(ran))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ran = nil
+	sum = runBatch(ctx, 10, BatchOptions{}, fn)
+	if sum.Err != context.Canceled || len(sum.Remaining) != 10 || len(ran) != 0 {
+		t.Errorf("canceled: %#v, ran %v\n", sum, ran)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+* File Name:	breaker.go
+* Description:  按接口熔断
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+//ErrCircuitOpen 接口已熔断, 请求未发出
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+//BreakerState 熔断器的状态
+type BreakerState int
+
+const (
+	//BreakerClosed 正常发出请求
+	BreakerClosed BreakerState = iota
+	//BreakerOpen 熔断中, 请求直接返回ErrCircuitOpen
+	BreakerOpen
+	//BreakerHalfOpen 熔断时间已过, 放行一个试探请求, 成功时恢复, 失败时重新熔断
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+//BreakerOptions 熔断器的配置
+type BreakerOptions struct {
+	Failures      int                                        //接口连续失败该次数后熔断, 不大于0时为5
+	Cooldown      time.Duration                              //熔断的持续时间, 不大于0时为30秒
+	OnStateChange func(ifname string, from, to BreakerState) //状态变化时调用, 调用时持有熔断器的锁, 不能调用Breaker的方法
+}
+
+//Breaker 熔断器, 按接口统计连续失败的请求. 网络错误、HTTP 5xx和限流记为失败,
+//返回的errorcode不影响熔断. 可以被多个客户端共用
+type Breaker struct {
+	opts      BreakerOptions
+	mu        sync.Mutex
+	endpoints map[string]*circuit
+}
+
+type circuit struct {
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool //半开状态下试探请求进行中
+}
+
+//NewBreaker 新建熔断器
+func NewBreaker(opts BreakerOptions) *Breaker {
+	if opts.Failures <= 0 {
+		opts.Failures = 5
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 30 * time.Second
+	}
+	return &Breaker{opts: opts, endpoints: make(map[string]*circuit)}
+}
+
+//WithBreaker 设置熔断器, 默认不熔断
+func WithBreaker(b *Breaker) Option {
+	return func(y *Youtu) {
+		y.breaker = b
+	}
+}
+
+//State 返回接口当前的状态, 熔断时间已过但还没有请求时仍为BreakerOpen
+func (b *Breaker) State(ifname string) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c, ok := b.endpoints[ifname]; ok {
+		return c.state
+	}
+	return BreakerClosed
+}
+
+//allow 判断接口能否发出请求
+func (b *Breaker) allow(ifname string, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.endpoints[ifname]
+	if !ok {
+		return nil
+	}
+	switch c.state {
+	case BreakerOpen:
+		if now.Sub(c.openedAt) < b.opts.Cooldown {
+			return ErrCircuitOpen
+		}
+		b.setLocked(ifname, c, BreakerHalfOpen)
+		c.probing = true
+	case BreakerHalfOpen:
+		if c.probing {
+			return ErrCircuitOpen
+		}
+		c.probing = true
+	}
+	return nil
+}
+
+//done 记录请求的结果. ctx结束导致的错误不计入
+func (b *Breaker) done(ctx context.Context, ifname string, err error, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.endpoints[ifname]
+	if !ok {
+		c = &circuit{}
+		b.endpoints[ifname] = c
+	}
+	c.probing = false
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+	if err == nil || !retryable(ctx, err) {
+		c.failures = 0
+		b.setLocked(ifname, c, BreakerClosed)
+		return
+	}
+	c.failures++
+	if c.state == BreakerHalfOpen || c.failures >= b.opts.Failures {
+		c.openedAt = now
+		b.setLocked(ifname, c, BreakerOpen)
+	}
+}
+
+func (b *Breaker) setLocked(ifname string, c *circuit, to BreakerState) {
+	from := c.state
+	if from == to {
+		return
+	}
+	c.state = to
+	if b.opts.OnStateChange != nil {
+		b.opts.OnStateChange(ifname, from, to)
+	}
+}
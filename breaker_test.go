@@ -0,0 +1,87 @@
+/*
+* File Name:	breaker_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type adjustableClock struct {
+	t time.Time
+}
+
+func (c *adjustableClock) Now() time.Time {
+	return c.t
+}
+
+func TestBreaker(t *testing.T) {
+	fail := true
+	calls := 0
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+	clock := &adjustableClock{t: time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)}
+	y.SetClock(clock)
+	var changes []string
+	b := NewBreaker(BreakerOptions{
+		Failures: 2,
+		Cooldown: time.Minute,
+		OnStateChange: func(ifname string, from, to BreakerState) {
+			changes = append(changes, fmt.Sprintf("%s %s->%s", ifname, from, to))
+		},
+	})
+	WithBreaker(b)(y)
+
+	for i := 0; i < 2; i++ {
+		if _, err := y.GetGroupIDs(); err == nil {
+			t.Errorf("GetGroupIDs succeeded, want server error\n")
+		}
+	}
+	if _, err := y.GetGroupIDs(); err != ErrCircuitOpen || calls != 2 {
+		t.Errorf("GetGroupIDs while open: %v after %d calls\n", err, calls)
+	}
+	//其它接口不受影响
+	if _, err := y.GetPersonIDs("g"); err == ErrCircuitOpen {
+		t.Errorf("GetPersonIDs failed fast\n")
+	}
+
+	//熔断时间过后试探失败, 重新熔断
+	clock.t = clock.t.Add(time.Minute)
+	calls = 0
+	if _, err := y.GetGroupIDs(); err == nil || err == ErrCircuitOpen || calls != 1 {
+		t.Errorf("probe: %v after %d calls\n", err, calls)
+	}
+	if b.State("getgroupids") != BreakerOpen {
+		t.Errorf("state after failed probe: %s\n", b.State("getgroupids"))
+	}
+
+	clock.t = clock.t.Add(time.Minute)
+	fail = false
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("probe failed: %s\n", err)
+	}
+	want := []string{
+		"getgroupids closed->open",
+		"getgroupids open->half-open",
+		"getgroupids half-open->open",
+		"getgroupids open->half-open",
+		"getgroupids half-open->closed",
+	}
+	if fmt.Sprint(changes) != fmt.Sprint(want) {
+		t.Errorf("state changes: %q, want %q\n", changes, want)
+	}
+}
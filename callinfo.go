@@ -0,0 +1,44 @@
+/*
+* File Name:	callinfo.go
+* Description:  调用的原始响应
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"net/http"
+)
+
+//CallInfo 一次调用最后一次请求的原始响应, 用于读取返回结构中还没有的字段
+type CallInfo struct {
+	RequestID  string      //请求ID
+	StatusCode int         //HTTP状态码, 没有收到响应时为0
+	Header     http.Header //响应头
+	Body       []byte      //响应体, 请求失败时为nil
+}
+
+type callInfoKey struct{}
+
+//ContextWithCallInfo 返回携带info的ctx, 以该ctx调用接口后, info中为该调用的原始响应.
+//不要在多个调用间共用同一个info
+func ContextWithCallInfo(ctx context.Context, info *CallInfo) context.Context {
+	return context.WithValue(ctx, callInfoKey{}, info)
+}
+
+func callInfoFrom(ctx context.Context) *CallInfo {
+	info, _ := ctx.Value(callInfoKey{}).(*CallInfo)
+	return info
+}
+
+//fill 以最后一次请求及其响应体填充info
+func (info *CallInfo) fill(ctx context.Context, call *Call, body []byte) {
+	info.RequestID, _ = RequestIDFromContext(ctx)
+	if call != nil {
+		info.StatusCode = call.StatusCode
+		info.Header = call.ResponseHeader
+	}
+	info.Body = body
+}
@@ -0,0 +1,37 @@
+/*
+* File Name:	callinfo_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCallInfo(t *testing.T) {
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Server", "test")
+		w.Write([]byte(`{"errorcode":0,"group_ids":["g"],"new_field":"v"}`))
+	})
+	defer srv.Close()
+	var info CallInfo
+	ggr, err := y.GetGroupIDsCtx(ContextWithCallInfo(context.Background(), &info))
+	if err != nil || len(ggr.GroupIDs) != 1 {
+		t.Errorf("GetGroupIDsCtx: %+v, %v\n", ggr, err)
+	}
+	var raw struct {
+		NewField string `json:"new_field"`
+	}
+	if err = json.Unmarshal(info.Body, &raw); err != nil || raw.NewField != "v" {
+		t.Errorf("raw body %q: %v\n", info.Body, err)
+	}
+	if info.StatusCode != 200 || info.Header.Get("X-Server") != "test" || len(info.RequestID) != 32 {
+		t.Errorf("call info: %+v\n", info)
+	}
+}
@@ -0,0 +1,74 @@
+//go:build gocv
+
+/*
+* File Name:	camera_gocv.go
+* Description:  基于gocv的摄像头
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package capture
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ochapman/youtu/stream"
+	"gocv.io/x/gocv"
+)
+
+//ErrCameraRead 读取摄像头失败错误
+var ErrCameraRead = errors.New("camera read failed")
+
+//Camera 本地摄像头, 实现stream.Source
+type Camera struct {
+	vc     *gocv.VideoCapture
+	mat    gocv.Mat
+	seq    int
+	closed bool
+}
+
+//OpenCamera 打开编号为device的摄像头, 如0为/dev/video0
+func OpenCamera(device int) (c *Camera, err error) {
+	vc, err := gocv.OpenVideoCapture(device)
+	if err != nil {
+		return
+	}
+	return &Camera{vc: vc, mat: gocv.NewMat()}, nil
+}
+
+//Next 读取一帧并编码为JPEG. 摄像头启动或丢帧时读到的空帧被跳过, 摄像头关闭后返回io.EOF
+func (c *Camera) Next() (f stream.Frame, err error) {
+	for {
+		if c.closed || !c.vc.IsOpened() {
+			return f, io.EOF
+		}
+		if !c.vc.Read(&c.mat) {
+			if !c.vc.IsOpened() {
+				return f, io.EOF
+			}
+			return f, ErrCameraRead
+		}
+		if !c.mat.Empty() {
+			break
+		}
+	}
+	buf, err := gocv.IMEncode(gocv.JPEGFileExt, c.mat)
+	if err != nil {
+		return
+	}
+	defer buf.Close()
+	data := make([]byte, buf.Len())
+	copy(data, buf.GetBytes())
+	f = stream.Frame{Seq: c.seq, Time: time.Now(), JPEG: data}
+	c.seq++
+	return
+}
+
+//Close 关闭摄像头
+func (c *Camera) Close() error {
+	c.closed = true
+	c.mat.Close()
+	return c.vc.Close()
+}
@@ -0,0 +1,72 @@
+/*
+* File Name:	capture.go
+* Description:  本地摄像头取帧检测与验证
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+//Package capture 从本地摄像头取帧, 检测人脸后与指定个体验证, 是门禁等自助终端的参考实现.
+//摄像头的读取基于gocv, 需要安装OpenCV并以-tags gocv编译; 检测与验证流程对任意stream.Source可用
+package capture
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"time"
+
+	"github.com/ochapman/youtu"
+	"github.com/ochapman/youtu/stream"
+)
+
+//VerifyEvent 一帧的检测与验证结果
+type VerifyEvent struct {
+	Seq    int                  //帧序号
+	Time   time.Time            //帧的时间
+	Detect youtu.DetectFaceRsp  //检测结果
+	Verify *youtu.FaceVerifyRsp //验证结果, 未检测到人脸时为nil
+	Err    error                //检测或验证失败的原因, 包括errorcode非0
+}
+
+//Matched 是否验证为同一人
+func (ev VerifyEvent) Matched() bool {
+	return ev.Err == nil && ev.Verify != nil && ev.Verify.Ismatch
+}
+
+//Verify 从src中每隔interval取一帧, 以大脸模式检测, 检测到人脸时与personID验证,
+//通过emit输出结果. emit返回false时停止. ctx取消或流结束时返回, 流正常结束时返回nil
+func Verify(ctx context.Context, y *youtu.Youtu, src stream.Source, personID string, interval time.Duration, emit func(VerifyEvent) bool) error {
+	var last time.Time
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		f, err := src.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !last.IsZero() && f.Time.Sub(last) < interval {
+			continue
+		}
+		last = f.Time
+		ev := VerifyEvent{Seq: f.Seq, Time: f.Time}
+		image := base64.StdEncoding.EncodeToString(f.JPEG)
+		ev.Detect, ev.Err = y.DetectFaceCtx(ctx, image, youtu.DetectModeBigFace)
+		if ev.Err == nil && ev.Detect.ErrorCode != 0 {
+			ev.Err = &youtu.APIError{Interface: "detectface", Code: ev.Detect.ErrorCode, Msg: ev.Detect.ErrorMsg}
+		}
+		if ev.Err == nil && len(ev.Detect.Face) > 0 {
+			fvr, err := y.FaceVerifyCtx(ctx, image, personID)
+			if err == nil && fvr.ErrorCode != 0 {
+				err = &youtu.APIError{Interface: "faceverify", Code: int(fvr.ErrorCode), Msg: fvr.ErrorMsg}
+			}
+			ev.Verify, ev.Err = &fvr, err
+		}
+		if !emit(ev) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+* File Name:	capture_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package capture
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ochapman/youtu"
+	"github.com/ochapman/youtu/stream"
+)
+
+//fakeSource 依次返回frames, 每帧间隔1秒
+type fakeSource struct {
+	frames []string
+	seq    int
+}
+
+func (s *fakeSource) Next() (f stream.Frame, err error) {
+	if s.seq == len(s.frames) {
+		return f, io.EOF
+	}
+	f = stream.Frame{Seq: s.seq, Time: time.Unix(int64(s.seq), 0), JPEG: []byte(s.frames[s.seq])}
+	s.seq++
+	return
+}
+
+func (s *fakeSource) Close() error {
+	return nil
+}
+
+func TestVerify(t *testing.T) {
+	verified := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		frame, _ := base64.StdEncoding.DecodeString(req["image"])
+		switch r.URL.Path {
+		case "/youtu/api/detectface":
+			if string(frame) == "empty" {
+				fmt.Fprint(w, `{"face":[],"errorcode":0}`)
+				return
+			}
+			fmt.Fprint(w, `{"face":[{"face_id":"f"}],"errorcode":0}`)
+		case "/youtu/api/faceverify":
+			verified++
+			fmt.Fprintf(w, `{"ismatch":%t,"confidence":80,"errorcode":0}`, string(frame) == "alice")
+		}
+	}))
+	defer api.Close()
+	as, _ := youtu.NewAppSign(12345678, "id", "key", 0, "user")
+	y := youtu.Init(as, youtu.WithHost(strings.TrimPrefix(api.URL, "http://")))
+
+	src := &fakeSource{frames: []string{"empty", "skipped", "bob", "skipped", "alice", "never"}}
+	var events []VerifyEvent
+	err := Verify(context.Background(), y, src, "alice", 2*time.Second, func(ev VerifyEvent) bool {
+		events = append(events, ev)
+		return !ev.Matched()
+	})
+	if err != nil {
+		t.Errorf("Verify failed: %s\n", err)
+	}
+	if len(events) != 3 || verified != 2 {
+		t.Errorf("events: %d verified: %d, want 3 2\n", len(events), verified)
+		return
+	}
+	if events[0].Verify != nil || events[1].Seq != 2 || events[1].Matched() || !events[2].Matched() {
+		t.Errorf("events: %+v\n", events)
+	}
+
+	src = &fakeSource{frames: []string{"alice"}}
+	matched := false
+	Verify(context.Background(), y, src, "alice", 0, func(ev VerifyEvent) bool {
+		matched = ev.Matched()
+		return true
+	})
+	if !matched {
+		t.Errorf("alice not matched\n")
+	}
+}
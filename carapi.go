@@ -0,0 +1,34 @@
+/*
+* File Name:	carapi.go
+* Description:  车辆识别接口的返回
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+//CarTag 车辆识别的一个候选
+type CarTag struct {
+	Brand      string  `json:"brand"`      //品牌
+	Model      string  `json:"model"`      //型号
+	Color      string  `json:"color"`      //颜色
+	Confidence float32 `json:"confidence"` //置信度[0~1]
+}
+
+//CarClassifyRsp 车辆属性识别返回
+type CarClassifyRsp struct {
+	CarCoord  ItemCoord `json:"carcoord"`  //车辆在图片中的位置
+	Tags      []CarTag  `json:"tags"`      //候选, 按置信度从高到低排列
+	ErrorCode int       `json:"errorcode"` //返回状态码
+	ErrorMsg  string    `json:"errormsg"`  //返回错误消息
+}
+
+//Best 返回置信度最高的候选, 没有候选时ok为false
+func (r CarClassifyRsp) Best() (tag CarTag, ok bool) {
+	for _, t := range r.Tags {
+		if !ok || t.Confidence > tag.Confidence {
+			tag, ok = t, true
+		}
+	}
+	return
+}
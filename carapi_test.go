@@ -0,0 +1,25 @@
+/*
+* File Name:	carapi_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import "testing"
+
+func TestCarClassify(t *testing.T) {
+	y, done := newImageAPIServer(t, "carapi/carclassify", `{"errorcode":0,"carcoord":{"x":10,"y":20,"width":300,"height":200},"tags":[{"brand":"大众","model":"帕萨特","color":"白色","confidence":0.62},{"brand":"大众","model":"迈腾","color":"白色","confidence":0.81}]}`)
+	defer done()
+	ccr, err := y.CarClassify("QUJD")
+	if err != nil || ccr.CarCoord.Width != 300 || len(ccr.Tags) != 2 {
+		t.Errorf("CarClassify: %+v, %v\n", ccr, err)
+	}
+	if best, ok := ccr.Best(); !ok || best.Model != "迈腾" {
+		t.Errorf("Best: %+v, %v\n", best, ok)
+	}
+	if _, ok := (CarClassifyRsp{}).Best(); ok {
+		t.Errorf("Best of empty tags reported ok\n")
+	}
+}
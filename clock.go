@@ -0,0 +1,130 @@
+/*
+* File Name:	clock.go
+* Description:  时间和随机数来源
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+//Clock 时间来源, 用于签名时间戳、审计日志等. 测试中可以替换为固定或可调的时间
+type Clock interface {
+	Now() time.Time
+}
+
+//Rand 随机数来源, 用于签名的随机数和重试的抖动, 不设置时签名的随机数取自crypto/rand. *rand.Rand满足该接口
+type Rand interface {
+	Int31() int32
+	Int63n(n int64) int64
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+//SystemClock 系统时间, 默认的时间来源
+var SystemClock Clock = systemClock{}
+
+//SetClock 设置时间来源, c为nil时使用SystemClock
+func (y *Youtu) SetClock(c Clock) {
+	y.clock = c
+}
+
+//SetRand 设置随机数来源, r为nil时使用默认来源. r不必是并发安全的, 调用时加锁
+func (y *Youtu) SetRand(r Rand) {
+	y.random = nil
+	if r != nil {
+		y.random = &lockedRand{r: r}
+	}
+}
+
+//lockedRand 串行化对Rand的调用, *rand.Rand不能被多个goroutine同时使用
+type lockedRand struct {
+	mu sync.Mutex
+	r  Rand
+}
+
+func (lr *lockedRand) Int31() int32 {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	return lr.r.Int31()
+}
+
+func (lr *lockedRand) Int63n(n int64) int64 {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	return lr.r.Int63n(n)
+}
+
+//WithClock 设置时间来源, 同SetClock
+func WithClock(c Clock) Option {
+	return func(y *Youtu) {
+		y.clock = c
+	}
+}
+
+//WithRand 设置随机数来源, 同SetRand
+func WithRand(r Rand) Option {
+	return func(y *Youtu) {
+		y.SetRand(r)
+	}
+}
+
+//fixedSign 固定的签名时间戳和随机数
+type fixedSign struct {
+	t     time.Time
+	nonce int32
+}
+
+//WithFixedSign 固定签名的时间戳为t, 随机数为nonce, 不影响超时、熔断等使用的时间来源.
+//签名因此逐字节确定, 用于golden测试, 见github.com/ochapman/youtu/yoututest
+func WithFixedSign(t time.Time, nonce int32) Option {
+	return func(y *Youtu) {
+		y.fixedSign = &fixedSign{t: t, nonce: nonce}
+	}
+}
+
+//WithClockSkew 签名的时间戳为本地时间加offset, 用于校正本地时钟与服务端的偏差:
+//本地时钟比服务端慢时offset为正. 偏差可以用Diagnose检查
+func WithClockSkew(offset time.Duration) Option {
+	return func(y *Youtu) {
+		y.clockSkew = offset
+	}
+}
+
+func (y *Youtu) now() time.Time {
+	if y.clock == nil {
+		return SystemClock.Now()
+	}
+	return y.clock.Now()
+}
+
+//signTime 返回签名使用的时间, 即校正偏差后的本地时间
+func (y *Youtu) signTime() time.Time {
+	if y.fixedSign != nil {
+		return y.fixedSign.t
+	}
+	return y.now().Add(y.clockSkew)
+}
+
+//nonce 返回签名用的非负随机数, 默认取自crypto/rand, 同一秒内启动的进程也不会重复
+func (y *Youtu) nonce() int32 {
+	if y.fixedSign != nil {
+		return y.fixedSign.nonce
+	}
+	if y.random != nil {
+		return y.random.Int31()
+	}
+	var b [4]byte
+	rand.Read(b[:])
+	return int32(binary.BigEndian.Uint32(b[:]) &^ (1 << 31))
+}
@@ -0,0 +1,120 @@
+/*
+* File Name:	clock_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//fixedClock 返回固定时间的Clock
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestSetClockRand(t *testing.T) {
+	y := Init(as, WithHost("localhost"))
+	y.SetClock(fixedClock(time.Unix(1500000000, 0)))
+	y.SetRand(rand.New(rand.NewSource(1)))
+	want := "a=12345678&k=your_secret_id&e=1436353609&t=1500000000&r=" +
+		"1298498081&u=your_qq_id&f="
+	if got := y.orignalSign(); got != want {
+		t.Errorf("orignalSign: %s, want %s\n", got, want)
+	}
+	y.SetRand(rand.New(rand.NewSource(1)))
+	first := y.sign()
+	y.SetRand(rand.New(rand.NewSource(1)))
+	if y.sign() != first {
+		t.Errorf("sign not deterministic with injected clock and rand\n")
+	}
+
+	var buf bytes.Buffer
+	y.SetAuditLog(NewAuditLog(&buf))
+	y.auditCall("newperson", []byte(`{}`), []byte(`{"errorcode":0}`), nil)
+	if !bytes.Contains(buf.Bytes(), []byte(`"time":"`+time.Unix(1500000000, 0).Format(time.RFC3339))) {
+		t.Errorf("audit time not from clock: %s\n", buf.String())
+	}
+}
+
+func TestNonce(t *testing.T) {
+	a := Init(as, WithHost("localhost"))
+	b := Init(as, WithHost("localhost"))
+	a.SetClock(fixedClock(time.Unix(1500000000, 0)))
+	b.SetClock(fixedClock(time.Unix(1500000000, 0)))
+	seen := make(map[int32]bool)
+	for i := 0; i < 10; i++ {
+		for _, y := range []*Youtu{a, b} {
+			n := y.nonce()
+			if n < 0 {
+				t.Errorf("nonce %d is negative\n", n)
+			}
+			seen[n] = true
+		}
+	}
+	if len(seen) != 20 {
+		t.Errorf("got %d distinct nonces in the same second, want 20\n", len(seen))
+	}
+}
+
+func TestSignConcurrent(t *testing.T) {
+	for _, r := range []Rand{nil, rand.New(rand.NewSource(1))} {
+		y := Init(as, WithHost("localhost"))
+		y.SetRand(r)
+		var wg sync.WaitGroup
+		signs := make([]string, 8)
+		for i := range signs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				signs[i] = y.sign()
+				y.int63n(100)
+			}(i)
+		}
+		wg.Wait()
+		for _, s := range signs {
+			if _, err := base64.StdEncoding.DecodeString(s); err != nil || s == "" {
+				t.Errorf("malformed signature %q\n", s)
+			}
+		}
+	}
+}
+
+func TestClockSkew(t *testing.T) {
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	defer srv.Close()
+	WithClock(fixedClock(time.Unix(1500000000, 0)))(y)
+	WithClockSkew(30 * time.Second)(y)
+	if s := y.orignalSign(); !strings.Contains(s, "&t=1500000030&") {
+		t.Errorf("orignalSign with skew: %s\n", s)
+	}
+	_, err := y.GetGroupIDs()
+	se, ok := err.(*StatusError)
+	if !ok || se.ClockSkew > -time.Hour || !strings.Contains(err.Error(), "WithClockSkew") {
+		t.Errorf("auth failure with skewed clock: %v\n", err)
+	}
+
+	//时钟正常时不提示
+	y.SetClock(nil)
+	WithClockSkew(0)(y)
+	_, err = y.GetGroupIDs()
+	if se, ok := err.(*StatusError); !ok || strings.Contains(err.Error(), "WithClockSkew") {
+		t.Errorf("auth failure with correct clock: %v\n", se)
+	}
+}
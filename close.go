@@ -0,0 +1,117 @@
+/*
+* File Name:	close.go
+* Description:  关闭客户端
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+//ErrClosed 客户端已关闭错误
+var ErrClosed = errors.New("youtu client closed")
+
+//lifecycle 客户端及其副本(WithPriority等返回的)共用的运行状态
+type lifecycle struct {
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+	stops    []func() //关闭时停止后台任务的函数
+}
+
+//begin 开始一个请求, 客户端已关闭时返回ErrClosed
+func (lc *lifecycle) begin() error {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if lc.closed {
+		return ErrClosed
+	}
+	lc.inflight.Add(1)
+	return nil
+}
+
+func (lc *lifecycle) end() {
+	lc.inflight.Done()
+}
+
+//onClose 注册关闭时调用的stop, 用于停止后台goroutine. 已关闭时立即调用
+func (lc *lifecycle) onClose(stop func()) {
+	lc.mu.Lock()
+	if !lc.closed {
+		lc.stops = append(lc.stops, stop)
+		lc.mu.Unlock()
+		return
+	}
+	lc.mu.Unlock()
+	stop()
+}
+
+//flusher 可以刷新缓冲的写入, 如*bufio.Writer
+type flusher interface {
+	Flush() error
+}
+
+//syncer 可以同步到磁盘的写入, 如*os.File
+type syncer interface {
+	Sync() error
+}
+
+//flushWriter 刷新w的缓冲并同步到磁盘
+func flushWriter(w interface{}) error {
+	if f, ok := w.(flusher); ok {
+		if err := f.Flush(); err != nil {
+			return err
+		}
+	}
+	if s, ok := w.(syncer); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+//Close 关闭客户端: 不再接受新的请求(返回ErrClosed), 等待进行中的请求完成,
+//停止后台任务, 刷新回滚日志和审计日志, 关闭客户端自己创建的Transport(如WithProxy, WithTransportOptions)的空闲连接.
+//ctx取消时不再等待进行中的请求, 返回ctx.Err(). 客户端的副本共用同一状态, 一起关闭.
+//回滚日志和审计日志的写入对象, 以及http.DefaultTransport和调用方提供的Transport由调用方关闭
+func (y *Youtu) Close(ctx context.Context) (err error) {
+	lc := y.lifecycle
+	lc.mu.Lock()
+	first := !lc.closed
+	lc.closed = true
+	stops := lc.stops
+	lc.stops = nil
+	lc.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		lc.inflight.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	for _, stop := range stops {
+		stop()
+	}
+	if y.journal != nil {
+		if ferr := y.journal.Flush(); err == nil {
+			err = ferr
+		}
+	}
+	if y.audit != nil {
+		if ferr := y.audit.Flush(); err == nil {
+			err = ferr
+		}
+	}
+	if first && y.ownTransport != nil && y.client.Transport == y.ownTransport {
+		y.ownTransport.CloseIdleConnections()
+	}
+	return
+}
@@ -0,0 +1,112 @@
+/*
+* File Name:	close_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClose(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-unblock
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+	var buf bytes.Buffer
+	bw := bufio.NewWriter(&buf)
+	j := NewJournal(bw)
+	j.append(InverseOp{Interface: "delperson", PersonID: "alice"})
+	y = y.WithJournal(j)
+	stopped := false
+	y.lifecycle.onClose(func() { stopped = true })
+
+	done := make(chan error)
+	go func() {
+		_, err := y.GetGroupIDs()
+		done <- err
+	}()
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := y.Close(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Close with request in flight: %v, want %v\n", err, context.DeadlineExceeded)
+	}
+	if _, err := y.WithPriority(PriorityInteractive).GetGroupIDs(); err != ErrClosed {
+		t.Errorf("request after Close: %v, want %v\n", err, ErrClosed)
+	}
+	close(unblock)
+	if err := <-done; err != nil {
+		t.Errorf("in-flight request failed: %s\n", err)
+	}
+	if err := y.Close(context.Background()); err != nil {
+		t.Errorf("Close failed: %s\n", err)
+	}
+	if !stopped {
+		t.Errorf("background task not stopped\n")
+	}
+	if buf.Len() == 0 {
+		t.Errorf("journal not flushed\n")
+	}
+}
+
+//closeCounter 调用方提供的Transport, 记录CloseIdleConnections的调用
+type closeCounter struct {
+	http.RoundTripper
+	closed int
+}
+
+func (c *closeCounter) CloseIdleConnections() {
+	c.closed++
+}
+
+func TestCloseTransport(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Write([]byte(`{"errorcode":0}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed <- struct{}{}
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+	host := srv.Listener.Addr().String()
+
+	cc := &closeCounter{RoundTripper: http.DefaultTransport}
+	y := Init(as, WithHost(host))
+	y.SetTransport(cc)
+	if err := y.Close(context.Background()); err != nil || cc.closed != 0 {
+		t.Errorf("Close with caller transport: %v, CloseIdleConnections called %d times\n", err, cc.closed)
+	}
+
+	y = Init(as, WithHost(host), WithTransportOptions(TransportOptions{MaxIdleConnsPerHost: 4}))
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs failed: %s\n", err)
+	}
+	if err := y.Close(context.Background()); err != nil {
+		t.Errorf("Close failed: %s\n", err)
+	}
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Errorf("idle connection of the client's own transport not closed\n")
+	}
+}
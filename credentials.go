@@ -0,0 +1,134 @@
+/*
+* File Name:	credentials.go
+* Description:  凭证的来源
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//CredentialProvider 凭证来源, 用于密钥定期轮换的场景. 每次请求签名前调用Retrieve,
+//实现应自行缓存凭证, 并且可以被多个goroutine同时调用
+type CredentialProvider interface {
+	Retrieve() (AppSign, error)
+}
+
+//WithCredentialProvider 签名前从p获取凭证, 轮换后的密钥无需重启即可生效.
+//请求中的app_id仍取自Init的AppSign, 轮换时应只更换secret_id和secret_key
+func WithCredentialProvider(p CredentialProvider) Option {
+	return func(y *Youtu) {
+		y.credentials = p
+	}
+}
+
+//凭证的环境变量
+const (
+	EnvAppID     = "YOUTU_APP_ID"
+	EnvSecretID  = "YOUTU_SECRET_ID"
+	EnvSecretKey = "YOUTU_SECRET_KEY"
+	EnvUserID    = "YOUTU_USER_ID"
+)
+
+//NewAppSignFromEnv 从环境变量YOUTU_APP_ID, YOUTU_SECRET_ID, YOUTU_SECRET_KEY和YOUTU_USER_ID
+//读取凭证, 前三个必须设置. 签名没有固定的过期时间, 通常与WithSignTTL一起使用
+func NewAppSignFromEnv() (as AppSign, err error) {
+	for _, name := range []string{EnvAppID, EnvSecretID, EnvSecretKey} {
+		if os.Getenv(name) == "" {
+			err = fmt.Errorf("environment variable %s not set", name)
+			return
+		}
+	}
+	appID, err := strconv.ParseUint(os.Getenv(EnvAppID), 10, 32)
+	if err != nil {
+		err = fmt.Errorf("environment variable %s: %v", EnvAppID, err)
+		return
+	}
+	return NewAppSign(uint32(appID), os.Getenv(EnvSecretID), os.Getenv(EnvSecretKey), 0, os.Getenv(EnvUserID))
+}
+
+//DefaultProfile 凭证文件中默认的配置名
+const DefaultProfile = "default"
+
+//NewAppSignFromFile 从凭证文件path读取名为profile的凭证, profile为空时使用DefaultProfile.
+//文件为INI格式, 可以有多个配置, #或;开头的行为注释:
+//
+//	[default]
+//	app_id = 12345678
+//	secret_id = xxx
+//	secret_key = xxx
+//	user_id = xxx
+//
+//	[test]
+//	...
+//
+//app_id, secret_id和secret_key必须设置. 签名没有固定的过期时间, 通常与WithSignTTL一起使用
+func NewAppSignFromFile(path, profile string) (as AppSign, err error) {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	var (
+		section string
+		found   bool
+		values  = make(map[string]string)
+	)
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case line == "" || line[0] == '#' || line[0] == ';':
+			continue
+		case line[0] == '[' && line[len(line)-1] == ']':
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			found = found || section == profile
+			continue
+		}
+		if section != profile {
+			continue
+		}
+		i := strings.Index(line, "=")
+		if i < 0 {
+			err = fmt.Errorf("%s:%d: expected key = value", path, n)
+			return
+		}
+		key, value := strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		switch key {
+		case "app_id", "secret_id", "secret_key", "user_id":
+			values[key] = value
+		default:
+			err = fmt.Errorf("%s:%d: unknown key %q", path, n, key)
+			return
+		}
+	}
+	if err = s.Err(); err != nil {
+		return
+	}
+	if !found {
+		err = fmt.Errorf("%s: profile %q not found", path, profile)
+		return
+	}
+	for _, key := range []string{"app_id", "secret_id", "secret_key"} {
+		if values[key] == "" {
+			err = fmt.Errorf("%s: profile %q: %s not set", path, profile, key)
+			return
+		}
+	}
+	appID, err := strconv.ParseUint(values["app_id"], 10, 32)
+	if err != nil {
+		err = fmt.Errorf("%s: profile %q: app_id: %v", path, profile, err)
+		return
+	}
+	return NewAppSign(uint32(appID), values["secret_id"], values["secret_key"], 0, values["user_id"])
+}
@@ -0,0 +1,132 @@
+/*
+* File Name:	credentials_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAppSignFromEnv(t *testing.T) {
+	t.Setenv(EnvAppID, "12345678")
+	t.Setenv(EnvSecretID, "your_secret_id")
+	t.Setenv(EnvSecretKey, "your_secret_key")
+	t.Setenv(EnvUserID, "your_qq_id")
+	got, err := NewAppSignFromEnv()
+	want := as
+	want.expired = 0
+	if err != nil || got != want {
+		t.Errorf("NewAppSignFromEnv: %+v, %v\n", got, err)
+	}
+
+	t.Setenv(EnvAppID, "app")
+	if _, err = NewAppSignFromEnv(); err == nil || !strings.Contains(err.Error(), EnvAppID) {
+		t.Errorf("malformed %s: %v\n", EnvAppID, err)
+	}
+	t.Setenv(EnvAppID, "12345678")
+	t.Setenv(EnvSecretKey, "")
+	if _, err = NewAppSignFromEnv(); err == nil || !strings.Contains(err.Error(), EnvSecretKey) {
+		t.Errorf("missing %s: %v\n", EnvSecretKey, err)
+	}
+}
+
+func TestNewAppSignFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	data := `# youtu credentials
+[default]
+app_id = 12345678
+secret_id = your_secret_id
+secret_key = your_secret_key
+user_id = your_qq_id
+
+; 测试环境
+[test]
+app_id=87654321
+secret_id=test_id
+secret_key=test_key
+
+[broken]
+app_id = 1
+secret_key = k
+`
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %s\n", err)
+	}
+	want := as
+	want.expired = 0
+	if got, err := NewAppSignFromFile(path, ""); err != nil || got != want {
+		t.Errorf("default profile: %+v, %v\n", got, err)
+	}
+	want = AppSign{appID: 87654321, secretID: "test_id", secretKey: "test_key"}
+	if got, err := NewAppSignFromFile(path, "test"); err != nil || got != want {
+		t.Errorf("test profile: %+v, %v\n", got, err)
+	}
+	for profile, msg := range map[string]string{
+		"broken":  "secret_id not set",
+		"missing": `profile "missing" not found`,
+	} {
+		if _, err := NewAppSignFromFile(path, profile); err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("profile %s: %v, want %s\n", profile, err, msg)
+		}
+	}
+	ioutil.WriteFile(path, []byte("[default]\napp_id = 1\nregion = gz\n"), 0600)
+	if _, err := NewAppSignFromFile(path, ""); err == nil || !strings.Contains(err.Error(), ":3: unknown key") {
+		t.Errorf("unknown key: %v\n", err)
+	}
+}
+
+//rotatingProvider 返回当前的凭证, 测试中可以随时轮换
+type rotatingProvider struct {
+	appSign AppSign
+	err     error
+	calls   int
+}
+
+func (p *rotatingProvider) Retrieve() (AppSign, error) {
+	p.calls++
+	return p.appSign, p.err
+}
+
+func TestCredentialProvider(t *testing.T) {
+	var secretIDs []string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		raw, _ := base64.StdEncoding.DecodeString(r.Header.Get("Authorization"))
+		for _, kv := range strings.Split(string(raw[sha1.Size:]), "&") {
+			if strings.HasPrefix(kv, "k=") {
+				secretIDs = append(secretIDs, kv[2:])
+			}
+		}
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+	p := &rotatingProvider{appSign: as}
+	WithCredentialProvider(p)(y)
+	WithSignTTL(time.Hour)(y)
+
+	y.GetGroupIDs()
+	y.GetGroupIDs()
+	p.appSign.secretID, p.appSign.secretKey = "rotated_secret_id", "rotated_secret_key"
+	y.GetGroupIDs()
+	want := []string{"your_secret_id", "your_secret_id", "rotated_secret_id"}
+	if strings.Join(secretIDs, ",") != strings.Join(want, ",") || p.calls != 3 {
+		t.Errorf("signed with %v after %d retrievals, want %v\n", secretIDs, p.calls, want)
+	}
+
+	p.err = errors.New("vault unavailable")
+	if _, err := y.GetGroupIDs(); err != p.err {
+		t.Errorf("GetGroupIDs with failing provider: %v, want %v\n", err, p.err)
+	}
+}
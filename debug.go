@@ -0,0 +1,87 @@
+/*
+* File Name:	debug.go
+* Description:  调试输出
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+//maxDebugString 调试输出中字符串的最大长度, 更长的字符串(如base64编码的图片)被截断
+const maxDebugString = 64
+
+//debugLog 输出请求和响应, 多个goroutine的输出不会交错
+type debugLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+//WithDebug 将每次请求和响应输出到w, 用于调试. 不输出Authorization头,
+//请求和响应中的长字符串(如base64编码的图片)被截断. w为nil时关闭
+func WithDebug(w io.Writer) Option {
+	return func(y *Youtu) {
+		y.debug = nil
+		if w != nil {
+			y.debug = &debugLog{w: w}
+		}
+	}
+}
+
+func (d *debugLog) request(req *http.Request, payload []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fmt.Fprintf(d.w, "> %s %s\n", req.Method, req.URL)
+	keys := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := req.Header.Get(k)
+		if k == "Authorization" {
+			v = "[redacted]"
+		}
+		fmt.Fprintf(d.w, "> %s: %s\n", k, v)
+	}
+	fmt.Fprintf(d.w, "> %s\n", redactDump(payload))
+}
+
+func (d *debugLog) response(resp *http.Response, body []byte, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if resp != nil {
+		fmt.Fprintf(d.w, "< %s\n", resp.Status)
+	}
+	if len(body) > 0 {
+		fmt.Fprintf(d.w, "< %s\n", redactDump(body))
+	}
+	if err != nil {
+		fmt.Fprintf(d.w, "! %s\n", err)
+	}
+}
+
+//redactDump 截断JSON中的长字符串, 不是JSON时截断整体
+func redactDump(data []byte) string {
+	out, err := rewriteJSONStrings(data, func(_, s string) interface{} {
+		return truncateDump(s, maxDebugString)
+	})
+	if err != nil {
+		return truncateDump(string(data), 4*maxDebugString)
+	}
+	return string(out)
+}
+
+func truncateDump(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return fmt.Sprintf("%s...(%d bytes)", s[:max/2], len(s))
+}
@@ -0,0 +1,34 @@
+/*
+* File Name:	debug_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDebug(t *testing.T) {
+	y, srv := newTestYoutu(`{"errorcode":0,"face":[]}`)
+	defer srv.Close()
+	var buf bytes.Buffer
+	WithDebug(&buf)(y)
+	image := strings.Repeat("QUJD", 1000)
+	if _, err := y.DetectFace(image, DetectModeNormal); err != nil {
+		t.Errorf("DetectFace failed: %s\n", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"> POST ", "/youtu/api/detectface", "> Authorization: [redacted]", "...(4000 bytes)", "< 200 OK", `"errorcode":0`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("debug output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, image) {
+		t.Errorf("debug output not redacted:\n%s", out)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+* File Name:	detect.go
+* Description:  人脸检测模式的自动选择
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"encoding/base64"
+	"image"
+	_ "image/jpeg" //注册jpeg解码
+	_ "image/png"  //注册png解码
+	"strings"
+)
+
+//SelfieMaxSide 图片长边不超过该值时认为是自拍大脸照, 优先使用大脸模式检测
+const SelfieMaxSide = 640
+
+//DetectFaceAuto 自动选择检测模式. 根据图片尺寸估计是否为自拍大脸照,
+//先用估计的模式检测, 没有检测到人脸时换另一种模式重试.
+//返回检测结果和最后使用的模式
+func (y *Youtu) DetectFaceAuto(imageData string) (dfr DetectFaceRsp, mode DetectMode, err error) {
+	return y.DetectFaceAutoCtx(context.Background(), imageData)
+}
+
+//DetectFaceAutoCtx 同DetectFaceAuto, ctx用于取消请求和设置截止时间
+func (y *Youtu) DetectFaceAutoCtx(ctx context.Context, imageData string) (dfr DetectFaceRsp, mode DetectMode, err error) {
+	modes := []DetectMode{DetectModeNormal, DetectModeBigFace}
+	if looksLikeSelfie(imageData) {
+		modes[0], modes[1] = modes[1], modes[0]
+	}
+	for _, mode = range modes {
+		dfr, err = y.DetectFaceCtx(ctx, imageData, mode)
+		if err != nil || len(dfr.Face) > 0 {
+			return
+		}
+	}
+	return
+}
+
+//looksLikeSelfie 根据图片尺寸估计是否为自拍大脸照, 无法解码时返回false
+func looksLikeSelfie(imageData string) bool {
+	dec := base64.NewDecoder(base64.StdEncoding, strings.NewReader(imageData))
+	cfg, _, err := image.DecodeConfig(dec)
+	if err != nil {
+		return false
+	}
+	return cfg.Width <= SelfieMaxSide && cfg.Height <= SelfieMaxSide
+}
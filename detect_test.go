@@ -0,0 +1,64 @@
+/*
+* File Name:	detect_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"testing"
+)
+
+//encodePNG 生成指定尺寸的png图片并编码
+func encodePNG(width, height int) string {
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)))
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestDetectFaceAuto(t *testing.T) {
+	var modes []DetectMode
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		var req detectFaceReq
+		json.NewDecoder(r.Body).Decode(&req)
+		modes = append(modes, req.Mode)
+		if req.Mode == DetectModeBigFace {
+			fmt.Fprint(w, `{"face":[{"face_id":"f"}],"errorcode":0}`)
+			return
+		}
+		fmt.Fprint(w, `{"face":[],"errorcode":0}`)
+	})
+	defer srv.Close()
+
+	cases := []struct {
+		image string
+		modes []DetectMode
+	}{
+		{encodePNG(1280, 960), []DetectMode{DetectModeNormal, DetectModeBigFace}},
+		{encodePNG(320, 480), []DetectMode{DetectModeBigFace}},
+		{"not an image", []DetectMode{DetectModeNormal, DetectModeBigFace}},
+	}
+	for i, c := range cases {
+		modes = nil
+		dfr, mode, err := y.DetectFaceAuto(c.image)
+		if err != nil {
+			t.Errorf("case %d: DetectFaceAuto failed: %s\n", i, err)
+			continue
+		}
+		if mode != DetectModeBigFace || len(dfr.Face) != 1 {
+			t.Errorf("case %d: mode %d, dfr %#v\n", i, mode, dfr)
+		}
+		if fmt.Sprint(modes) != fmt.Sprint(c.modes) {
+			t.Errorf("case %d: tried modes %v, want %v\n", i, modes, c.modes)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+/*
+* File Name:	diff.go
+* Description:  检测结果对比
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+//DiffOptions 对比检测结果的选项. 属性的容差为允许的变化量, 超出时记为漂移
+type DiffOptions struct {
+	MinIoU              float64 //两次检测的人脸框交并比不低于该值时认为是同一人脸, 不大于0时使用0.5
+	AgeTolerance        int32   //年龄的容差
+	GenderTolerance     int32   //性别的容差
+	ExpressionTolerance int32   //表情的容差
+	PoseTolerance       int32   //姿态(pitch, yaw, roll)的容差
+}
+
+//DefaultDiffOptions 默认的对比选项
+var DefaultDiffOptions = DiffOptions{
+	MinIoU:              0.5,
+	AgeTolerance:        5,
+	GenderTolerance:     20,
+	ExpressionTolerance: 20,
+	PoseTolerance:       10,
+}
+
+//FaceDrift 同一人脸在两次检测中超出容差的属性变化
+type FaceDrift struct {
+	Before  Face
+	After   Face
+	IoU     float64  //两次人脸框的交并比
+	Changes []string //变化的属性, 如"age 30->41"
+}
+
+//ImageDiff 一张图片两次检测结果的差异
+type ImageDiff struct {
+	Image   string      //图片的标识, 即结果集中的键
+	Added   []Face      //仅在后一次检测到的人脸
+	Removed []Face      //仅在前一次检测到的人脸
+	Drifted []FaceDrift //属性有漂移的人脸
+}
+
+//DiffReport 两个检测结果集的对比报告
+type DiffReport struct {
+	Compared   int         //两个结果集都有的图片数
+	Images     []ImageDiff //有差异的图片, 按标识排序
+	OnlyBefore []string    //仅在前一个结果集中的图片
+	OnlyAfter  []string    //仅在后一个结果集中的图片
+}
+
+//DiffDetections 对比同一批图片在两次检测中的结果, 如预处理改动前后对同一图片集的DetectFace结果,
+//报告新增、消失的人脸和属性漂移, 用于预处理改动的回归测试. 结果集以图片标识为键
+func DiffDetections(before, after map[string]DetectFaceRsp, opts DiffOptions) (r DiffReport) {
+	if opts.MinIoU <= 0 {
+		opts.MinIoU = 0.5
+	}
+	for image, b := range before {
+		a, ok := after[image]
+		if !ok {
+			r.OnlyBefore = append(r.OnlyBefore, image)
+			continue
+		}
+		r.Compared++
+		if d := diffFaces(image, b.Face, a.Face, opts); len(d.Added)+len(d.Removed)+len(d.Drifted) > 0 {
+			r.Images = append(r.Images, d)
+		}
+	}
+	for image := range after {
+		if _, ok := before[image]; !ok {
+			r.OnlyAfter = append(r.OnlyAfter, image)
+		}
+	}
+	sort.Slice(r.Images, func(i, j int) bool { return r.Images[i].Image < r.Images[j].Image })
+	sort.Strings(r.OnlyBefore)
+	sort.Strings(r.OnlyAfter)
+	return
+}
+
+//diffFaces 按交并比从高到低贪心匹配两次检测的人脸
+func diffFaces(image string, before, after []Face, opts DiffOptions) (d ImageDiff) {
+	d.Image = image
+	type pair struct {
+		i, j int
+		iou  float64
+	}
+	var pairs []pair
+	for i := range before {
+		for j := range after {
+			if iou := faceIoU(before[i], after[j]); iou >= opts.MinIoU {
+				pairs = append(pairs, pair{i, j, iou})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(a, b int) bool { return pairs[a].iou > pairs[b].iou })
+	matchedBefore := make([]bool, len(before))
+	matchedAfter := make([]bool, len(after))
+	for _, p := range pairs {
+		if matchedBefore[p.i] || matchedAfter[p.j] {
+			continue
+		}
+		matchedBefore[p.i], matchedAfter[p.j] = true, true
+		if changes := attributeChanges(before[p.i], after[p.j], opts); len(changes) > 0 {
+			d.Drifted = append(d.Drifted, FaceDrift{Before: before[p.i], After: after[p.j], IoU: p.iou, Changes: changes})
+		}
+	}
+	for i, ok := range matchedBefore {
+		if !ok {
+			d.Removed = append(d.Removed, before[i])
+		}
+	}
+	for j, ok := range matchedAfter {
+		if !ok {
+			d.Added = append(d.Added, after[j])
+		}
+	}
+	return
+}
+
+//faceIoU 计算两个人脸框的交并比
+func faceIoU(a, b Face) float64 {
+	ax0, ay0 := float64(a.X), float64(a.Y)
+	ax1, ay1 := ax0+float64(a.Width), ay0+float64(a.Height)
+	bx0, by0 := float64(b.X), float64(b.Y)
+	bx1, by1 := bx0+float64(b.Width), by0+float64(b.Height)
+	w := minFloat(ax1, bx1) - maxFloat(ax0, bx0)
+	h := minFloat(ay1, by1) - maxFloat(ay0, by0)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	inter := w * h
+	union := float64(a.Width)*float64(a.Height) + float64(b.Width)*float64(b.Height) - inter
+	return inter / union
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func attributeChanges(b, a Face, opts DiffOptions) (changes []string) {
+	check := func(name string, before, after, tolerance int32) {
+		if abs(after-before) > tolerance {
+			changes = append(changes, fmt.Sprintf("%s %d->%d", name, before, after))
+		}
+	}
+	check("age", b.Age, a.Age, opts.AgeTolerance)
+	check("gender", b.Gender, a.Gender, opts.GenderTolerance)
+	check("expression", b.Expression, a.Expression, opts.ExpressionTolerance)
+	check("pitch", b.Pitch, a.Pitch, opts.PoseTolerance)
+	check("yaw", b.Yaw, a.Yaw, opts.PoseTolerance)
+	check("roll", b.Roll, a.Roll, opts.PoseTolerance)
+	if b.Glass != a.Glass {
+		changes = append(changes, fmt.Sprintf("glass %t->%t", b.Glass, a.Glass))
+	}
+	return
+}
+
+//WriteText 以文本格式输出报告
+func (r DiffReport) WriteText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "compared %d images, %d changed\n", r.Compared, len(r.Images))
+	for _, d := range r.Images {
+		fmt.Fprintf(w, "%s:\n", d.Image)
+		for _, f := range d.Removed {
+			fmt.Fprintf(w, "  - face (%d,%d %gx%g)\n", f.X, f.Y, f.Width, f.Height)
+		}
+		for _, f := range d.Added {
+			fmt.Fprintf(w, "  + face (%d,%d %gx%g)\n", f.X, f.Y, f.Width, f.Height)
+		}
+		for _, dr := range d.Drifted {
+			fmt.Fprintf(w, "  ~ face (%d,%d %gx%g) iou %.2f: %v\n", dr.After.X, dr.After.Y, dr.After.Width, dr.After.Height, dr.IoU, dr.Changes)
+		}
+	}
+	for _, image := range r.OnlyBefore {
+		fmt.Fprintf(w, "only in before: %s\n", image)
+	}
+	for _, image := range r.OnlyAfter {
+		_, err = fmt.Fprintf(w, "only in after: %s\n", image)
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+/*
+* File Name:	diff_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDiffDetections(t *testing.T) {
+	before := map[string]DetectFaceRsp{
+		"a.jpg": {Face: []Face{
+			{X: 10, Y: 10, Width: 100, Height: 100, Age: 30},
+			{X: 300, Y: 10, Width: 80, Height: 80},
+		}},
+		"b.jpg":    {Face: []Face{{X: 0, Y: 0, Width: 50, Height: 50, Age: 20}}},
+		"gone.jpg": {},
+	}
+	after := map[string]DetectFaceRsp{
+		"a.jpg": {Face: []Face{
+			{X: 12, Y: 12, Width: 100, Height: 100, Age: 41},
+			{X: 600, Y: 10, Width: 80, Height: 80},
+		}},
+		"b.jpg":   {Face: []Face{{X: 1, Y: 1, Width: 50, Height: 50, Age: 23}}},
+		"new.jpg": {},
+	}
+	r := DiffDetections(before, after, DefaultDiffOptions)
+	if r.Compared != 2 || len(r.Images) != 1 {
+		t.Errorf("report: %+v\n", r)
+		return
+	}
+	d := r.Images[0]
+	if d.Image != "a.jpg" || len(d.Added) != 1 || len(d.Removed) != 1 || len(d.Drifted) != 1 {
+		t.Errorf("a.jpg diff: %+v\n", d)
+		return
+	}
+	if d.Removed[0].X != 300 || d.Added[0].X != 600 || d.Drifted[0].Changes[0] != "age 30->41" {
+		t.Errorf("a.jpg diff: %+v\n", d)
+	}
+	if len(r.OnlyBefore) != 1 || r.OnlyBefore[0] != "gone.jpg" || len(r.OnlyAfter) != 1 || r.OnlyAfter[0] != "new.jpg" {
+		t.Errorf("only before: %v only after: %v\n", r.OnlyBefore, r.OnlyAfter)
+	}
+	var buf bytes.Buffer
+	r.WriteText(&buf)
+	if !strings.Contains(buf.String(), "~ face (12,12 100x100) iou 0.92: [age 30->41]") {
+		t.Errorf("WriteText:\n%s", buf.String())
+	}
+}
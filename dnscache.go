@@ -0,0 +1,96 @@
+/*
+* File Name:	dnscache.go
+* Description:  域名解析缓存
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+//DefaultDNSTTL 域名解析结果的默认缓存时间
+const DefaultDNSTTL = 5 * time.Minute
+
+//DNSCache 缓存域名解析结果, 新建连接时不必每次解析. 可以被多个客户端共用
+type DNSCache struct {
+	ttl      time.Duration
+	resolver *net.Resolver
+	now      func() time.Time
+	mu       sync.Mutex
+	entries  map[string]dnsEntry
+}
+
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+//NewDNSCache 新建域名解析缓存, ttl不大于0时使用DefaultDNSTTL
+func NewDNSCache(ttl time.Duration) *DNSCache {
+	if ttl <= 0 {
+		ttl = DefaultDNSTTL
+	}
+	return &DNSCache{
+		ttl:      ttl,
+		resolver: net.DefaultResolver,
+		now:      time.Now,
+		entries:  make(map[string]dnsEntry),
+	}
+}
+
+//LookupHost 返回host的地址, 缓存过期时重新解析
+func (c *DNSCache) LookupHost(ctx context.Context, host string) ([]string, error) {
+	now := c.now()
+	c.mu.Lock()
+	e, ok := c.entries[host]
+	c.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.addrs, nil
+	}
+	addrs, err := c.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[host] = dnsEntry{addrs: addrs, expires: now.Add(c.ttl)}
+	c.mu.Unlock()
+	return addrs, nil
+}
+
+//dialer 返回先从缓存解析地址再以dial依次连接各地址的DialFunc, dial为nil时使用net.Dialer
+func (c *DNSCache) dialer(dial DialFunc) DialFunc {
+	if dial == nil {
+		var d net.Dialer
+		dial = d.DialContext
+	}
+	return func(ctx context.Context, network, addr string) (conn net.Conn, err error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil || net.ParseIP(host) != nil {
+			return dial(ctx, network, addr)
+		}
+		addrs, err := c.LookupHost(ctx, host)
+		if err != nil {
+			return
+		}
+		for _, ip := range addrs {
+			if conn, err = dial(ctx, network, net.JoinHostPort(ip, port)); err == nil {
+				return
+			}
+		}
+		return
+	}
+}
+
+//WithDNSCache 以c缓存服务地址的解析结果, 解析后的地址以WithDialer设置的dial连接. 在Init之后调用Warmup
+//可以预先解析服务地址. 同WithProxy在Init的最后应用
+func WithDNSCache(c *DNSCache) Option {
+	return func(y *Youtu) {
+		y.dnsCache = c
+	}
+}
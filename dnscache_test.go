@@ -0,0 +1,55 @@
+/*
+* File Name:	dnscache_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDNSCache(t *testing.T) {
+	_, srv := newTestYoutu(`{"errorcode":0}`)
+	defer srv.Close()
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(srv.URL, "http://"))
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	c := NewDNSCache(time.Minute)
+	c.now = func() time.Time { return now }
+	//youtu.invalid无法解析, 只能从缓存得到地址
+	c.entries["youtu.invalid"] = dnsEntry{addrs: []string{"127.0.0.1"}, expires: now.Add(time.Minute)}
+
+	y := Init(as, WithHost(net.JoinHostPort("youtu.invalid", port)), WithDNSCache(c))
+	if err := y.Warmup(context.Background()); err != nil {
+		t.Errorf("Warmup failed: %s\n", err)
+	}
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs with cached address failed: %s\n", err)
+	}
+
+	//WithDialer在WithDNSCache之后时仍然使用缓存, 解析出的地址以dial连接
+	dialed := ""
+	var d net.Dialer
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = addr
+		return d.DialContext(ctx, network, addr)
+	}
+	y2 := Init(as, WithHost(net.JoinHostPort("youtu.invalid", port)), WithDNSCache(c), WithDialer(dial))
+	if _, err := y2.GetGroupIDs(); err != nil || dialed != net.JoinHostPort("127.0.0.1", port) {
+		t.Errorf("GetGroupIDs with dialer and cache: %v, dialed %q\n", err, dialed)
+	}
+	y2.Close(context.Background())
+
+	now = now.Add(time.Minute)
+	y.client.Transport.(*http.Transport).CloseIdleConnections()
+	if _, err := y.GetGroupIDs(); err == nil {
+		t.Errorf("GetGroupIDs after cache expired succeeded\n")
+	}
+}
@@ -0,0 +1,127 @@
+/*
+* File Name:	doctor.go
+* Description:  接入问题诊断
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//MaxClockSkew 本地时间与服务端时间的最大允许偏差, 超出时签名的时间戳可能被拒绝
+const MaxClockSkew = time.Minute
+
+//Check 一项诊断的结果
+type Check struct {
+	Name   string //诊断项: dns, connect, clock, sign, credential
+	OK     bool   //是否通过
+	Detail string //结果说明或失败原因
+}
+
+//Diagnose 依次诊断域名解析、连接、时钟偏差、签名生成和凭证, 返回各项结果.
+//前一项失败导致无法进行的项记为失败. 凭证通过一次GetGroupIDs调用检查
+func (y *Youtu) Diagnose(ctx context.Context) (checks []Check) {
+	add := func(name string, err error, ok string) bool {
+		c := Check{Name: name, OK: err == nil, Detail: ok}
+		if err != nil {
+			c.Detail = err.Error()
+		}
+		checks = append(checks, c)
+		return c.OK
+	}
+
+	host, _, err := net.SplitHostPort(y.host)
+	if err != nil {
+		host = y.host
+	}
+	if y.dial != nil {
+		err = nil
+		add("dns", nil, "custom dialer, not resolved")
+	} else {
+		addrs, e := net.DefaultResolver.LookupHost(ctx, host)
+		err = e
+		add("dns", err, fmt.Sprintf("%s -> %s", host, strings.Join(addrs, ", ")))
+	}
+
+	var date time.Time
+	start := y.now()
+	if err == nil {
+		date, err = y.serverDate(ctx)
+	}
+	connected := add("connect", err, fmt.Sprintf("%s in %s", y.baseURL(), y.now().Sub(start).Round(time.Millisecond)))
+
+	switch {
+	case !connected:
+		add("clock", fmt.Errorf("server not reachable"), "")
+	case date.IsZero():
+		add("clock", fmt.Errorf("server sent no Date header"), "")
+	default:
+		skew := y.signTime().Sub(date)
+		if skew < 0 {
+			skew = -skew
+		}
+		err = nil
+		if skew > MaxClockSkew {
+			err = fmt.Errorf("local clock differs from server by %s (max %s), check the clock or use WithClockSkew", skew.Round(time.Second), MaxClockSkew)
+		}
+		add("clock", err, fmt.Sprintf("skew %s", skew.Round(time.Second)))
+	}
+
+	add("sign", y.checkSign(), "signature generated")
+
+	if !connected {
+		add("credential", fmt.Errorf("server not reachable"), "")
+		return
+	}
+	ggr, err := y.GetGroupIDsCtx(ctx)
+	if err == nil {
+		err = checkCode("getgroupids", int(ggr.ErrorCode), ggr.ErrorMsg)
+	}
+	add("credential", err, fmt.Sprintf("app_id %d accepted, %d groups", y.appSign.appID, len(ggr.GroupIDs)))
+	return
+}
+
+//serverDate 向服务端发送HEAD请求, 返回Date头的时间. 没有Date头时返回零值
+func (y *Youtu) serverDate(ctx context.Context) (date time.Time, err error) {
+	req, err := http.NewRequest("HEAD", y.baseURL()+"/", nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("User-Agent", y.userAgent)
+	ctx, cancel := context.WithTimeout(ctx, timeoutNormal.duration())
+	defer cancel()
+	resp, err := y.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+	date, _ = http.ParseTime(resp.Header.Get("Date"))
+	return
+}
+
+//checkSign 检查签名能否生成以及签名的有效期
+func (y *Youtu) checkSign() error {
+	as := y.appSign
+	if as.appID == 0 || as.secretID == "" || as.secretKey == "" {
+		return fmt.Errorf("app_id, secret_id and secret_key are required")
+	}
+	now := y.signTime()
+	if expired := y.expiry(now); expired != 0 && int64(expired) < now.Unix() {
+		return fmt.Errorf("signature expired at %s", time.Unix(int64(expired), 0).Format(time.RFC3339))
+	}
+	raw, err := base64.StdEncoding.DecodeString(y.sign())
+	if err != nil || len(raw) <= sha1.Size {
+		return fmt.Errorf("malformed signature")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+/*
+* File Name:	doctor_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDiagnose(t *testing.T) {
+	serverTime := time.Date(2015, 7, 8, 10, 0, 0, 0, time.UTC)
+	errorcode := "0"
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+		w.Write([]byte(`{"group_ids":["g1"],"errorcode":` + errorcode + `}`))
+	})
+	defer srv.Close()
+	y.SetClock(fixedClock(serverTime.Add(-10 * time.Second)))
+
+	checks := y.Diagnose(context.Background())
+	want := []string{"dns", "connect", "clock", "sign", "credential"}
+	if len(checks) != len(want) {
+		t.Errorf("checks: %+v\n", checks)
+		return
+	}
+	for i, c := range checks {
+		if c.Name != want[i] || !c.OK {
+			t.Errorf("check %d: %+v, want %s passed\n", i, c, want[i])
+		}
+	}
+
+	y.SetClock(fixedClock(serverTime.Add(2 * time.Hour)))
+	errorcode = "-1"
+	checks = y.Diagnose(context.Background())
+	for _, c := range checks {
+		//时钟快了两小时, 签名也随之过期
+		wantOK := c.Name == "dns" || c.Name == "connect"
+		if c.OK != wantOK {
+			t.Errorf("check %+v, want ok=%t\n", c, wantOK)
+		}
+	}
+}
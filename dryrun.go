@@ -0,0 +1,60 @@
+/*
+* File Name:	dryrun.go
+* Description:  修改类接口的dry-run模式
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"fmt"
+	"io"
+)
+
+//DryRunCall dry-run模式下本应发出的一次请求
+type DryRunCall struct {
+	Interface string //接口名
+	Request   []byte //JSON编码的请求
+}
+
+//DryRunFunc 记录dry-run模式下本应发出的请求
+type DryRunFunc func(call DryRunCall)
+
+//SetDryRun 开启整个客户端的dry-run模式: 修改类接口(NewPerson, AddFace, DelPerson,
+//DelFace, SetInfo及注册为Mutating的接口)不发出请求, 只交给record记录, 并返回零值的结果.
+//查询类接口照常请求. record为nil时关闭dry-run模式
+func (y *Youtu) SetDryRun(record DryRunFunc) {
+	y.dryRun = record
+}
+
+//DryRun 返回开启了dry-run模式的客户端副本, 用于单次调用, 原客户端不受影响
+func (y *Youtu) DryRun(record DryRunFunc) *Youtu {
+	c := *y
+	c.dryRun = record
+	return &c
+}
+
+//DryRunWriter 返回将请求逐行写入w的DryRunFunc, 过长的字段(如图片数据)只输出长度
+func DryRunWriter(w io.Writer) DryRunFunc {
+	return func(call DryRunCall) {
+		fmt.Fprintf(w, "%s %s\n", call.Interface, abbreviate(call.Request))
+	}
+}
+
+//abbrevLen 超过该长度的字符串字段在输出时省略
+const abbrevLen = 64
+
+//abbreviate 将JSON中过长的字符串替换为其长度, 解析失败时原样返回
+func abbreviate(data []byte) []byte {
+	out, err := rewriteJSONStrings(data, func(_, s string) interface{} {
+		if len(s) > abbrevLen {
+			return fmt.Sprintf("<%d bytes>", len(s))
+		}
+		return s
+	})
+	if err != nil {
+		return data
+	}
+	return out
+}
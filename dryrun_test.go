@@ -0,0 +1,54 @@
+/*
+* File Name:	dryrun_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	var paths []string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		fmt.Fprint(w, `{"errorcode":0}`)
+	})
+	defer srv.Close()
+	var buf bytes.Buffer
+	dry := y.DryRun(DryRunWriter(&buf))
+	image := strings.Repeat("A", 1000)
+	if _, err := dry.AddFace([]string{image}, "p", "tag"); err != nil {
+		t.Errorf("AddFace failed: %s\n", err)
+	}
+	if _, err := dry.DelPerson("p"); err != nil {
+		t.Errorf("DelPerson failed: %s\n", err)
+	}
+	if _, err := dry.GetInfo("p"); err != nil {
+		t.Errorf("GetInfo failed: %s\n", err)
+	}
+	if len(paths) != 1 || paths[0] != "/youtu/api/getinfo" {
+		t.Errorf("dry run sent: %v\n", paths)
+	}
+	want := `addface {"app_id":"12345678","images":["<1000 bytes>"],"person_id":"p","tag":"tag"}` + "\n" +
+		`delperson {"app_id":"12345678","person_id":"p"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("dry run log: %q, want %q\n", buf.String(), want)
+	}
+
+	//原客户端不受影响
+	paths = nil
+	if _, err := y.DelPerson("p"); err != nil {
+		t.Errorf("DelPerson failed: %s\n", err)
+	}
+	if len(paths) != 1 {
+		t.Errorf("sent: %v\n", paths)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+* File Name:	duplicate.go
+* Description:  跨组重复个体检测
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+)
+
+//ImageSource 返回个体的代表性人脸图片(base64编码).
+//接口不支持按face_id取回图片, 需要调用方从自己的存储中提供
+type ImageSource func(personID string) (image string, err error)
+
+//DuplicateCandidate 疑似同一人以不同ID注册在不同组中的一对个体, 是合并的候选
+type DuplicateCandidate struct {
+	PersonID      string  //个体ID
+	GroupID       string  //个体所在的组
+	MatchPersonID string  //疑似重复的个体ID
+	MatchGroupID  string  //疑似重复的个体所在的组
+	Confidence    float32 //识别的置信度
+}
+
+//DuplicateReport 重复个体检测报告
+type DuplicateReport struct {
+	Candidates []DuplicateCandidate //按置信度从高到低排列
+	Errors     map[string]error     //处理失败的个体ID及原因
+	Summary    BatchSummary         //按个体计的执行情况, 提前停止时未检测的个体不在报告中
+}
+
+//FindDuplicates 在groupIDs指定的组之间检测重复注册的个体.
+//对每个个体取其代表性人脸, 在它不属于的其他组中识别, 识别到不同ID且置信度不低于threshold时
+//作为合并候选. 同一ID出现在多个组中不算重复.
+//ctx取消或超出opts.Budget时提前停止, 已检测的结果仍会返回
+func (y *Youtu) FindDuplicates(ctx context.Context, groupIDs []string, source ImageSource, threshold float32, opts BatchOptions) (report DuplicateReport, err error) {
+	var persons []string
+	homes := make(map[string]string)
+	member := make(map[string]map[string]bool)
+	for _, groupID := range groupIDs {
+		gpr, err := y.GetPersonIDsCtx(ctx, groupID)
+		if err != nil {
+			return report, err
+		}
+		if err = checkCode("getpersonids", int(gpr.ErrorCode), gpr.ErrorMsg); err != nil {
+			return report, err
+		}
+		for _, personID := range gpr.PersonIDs {
+			if _, ok := homes[personID]; !ok {
+				homes[personID] = groupID
+				member[personID] = make(map[string]bool)
+				persons = append(persons, personID)
+			}
+			member[personID][groupID] = true
+		}
+	}
+
+	found := make([][]DuplicateCandidate, len(persons))
+	errs := make([]error, len(persons))
+	report.Summary = runBatch(ctx, len(persons), opts, func(i int) {
+		personID := persons[i]
+		image, err := source(personID)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		for _, other := range groupIDs {
+			if member[personID][other] {
+				continue
+			}
+			fir, err := y.FaceIdentifyCtx(ctx, image, other)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			if fir.ErrorCode != 0 || fir.PersonID == "" || fir.PersonID == personID || fir.Confidence < threshold {
+				continue
+			}
+			found[i] = append(found[i], DuplicateCandidate{
+				PersonID:      personID,
+				GroupID:       homes[personID],
+				MatchPersonID: fir.PersonID,
+				MatchGroupID:  other,
+				Confidence:    fir.Confidence,
+			})
+		}
+	})
+
+	report.Errors = make(map[string]error)
+	best := make(map[[2]string]DuplicateCandidate)
+	for i, cs := range found {
+		if errs[i] != nil {
+			report.Errors[persons[i]] = errs[i]
+		}
+		for _, c := range cs {
+			key := [2]string{c.PersonID, c.MatchPersonID}
+			if key[0] > key[1] {
+				key[0], key[1] = key[1], key[0]
+			}
+			if prev, ok := best[key]; !ok || c.Confidence > prev.Confidence {
+				best[key] = c
+			}
+		}
+	}
+	for _, c := range best {
+		report.Candidates = append(report.Candidates, c)
+	}
+	sort.Slice(report.Candidates, func(i, j int) bool {
+		ci, cj := report.Candidates[i], report.Candidates[j]
+		if ci.Confidence != cj.Confidence {
+			return ci.Confidence > cj.Confidence
+		}
+		return ci.PersonID < cj.PersonID
+	})
+	return
+}
+
+//WriteCSV 以CSV格式输出合并候选
+func (r DuplicateReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"person_id", "group_id", "match_person_id", "match_group_id", "confidence"})
+	for _, c := range r.Candidates {
+		cw.Write([]string{
+			c.PersonID,
+			c.GroupID,
+			c.MatchPersonID,
+			c.MatchGroupID,
+			strconv.FormatFloat(float64(c.Confidence), 'f', 2, 32),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
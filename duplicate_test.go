@@ -0,0 +1,72 @@
+/*
+* File Name:	duplicate_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	groups := map[string][]string{
+		"g1": {"alice", "bob"},
+		"g2": {"alice2", "carol", "bob"},
+	}
+	//每个个体的图片在另一组中识别出的结果
+	identify := map[string]string{
+		"alice@g2":  `{"person_id":"alice2","confidence":92}`,
+		"alice2@g1": `{"person_id":"alice","confidence":88}`,
+		"bob@g1":    `{"person_id":"bob","confidence":99}`,
+		"bob@g2":    `{"person_id":"bob","confidence":99}`,
+		"carol@g1":  `{"person_id":"alice","confidence":40}`,
+	}
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		switch r.URL.Path {
+		case "/youtu/api/getpersonids":
+			ids, _ := json.Marshal(groups[req["group_id"]])
+			fmt.Fprintf(w, `{"person_ids":%s,"errorcode":0}`, ids)
+		case "/youtu/api/faceidentify":
+			if rsp, ok := identify[req["image"]+"@"+req["group_id"]]; ok {
+				fmt.Fprint(w, rsp)
+				return
+			}
+			fmt.Fprint(w, `{"errorcode":-1001}`)
+		}
+	})
+	defer srv.Close()
+	source := func(personID string) (string, error) {
+		return personID, nil
+	}
+	report, err := y.FindDuplicates(context.Background(), []string{"g1", "g2"}, source, 80, BatchOptions{})
+	if err != nil {
+		t.Errorf("FindDuplicates failed: %s\n", err)
+		return
+	}
+	if len(report.Candidates) != 1 {
+		t.Errorf("candidates: %#v\n", report.Candidates)
+		return
+	}
+	c := report.Candidates[0]
+	if c.PersonID != "alice" || c.MatchPersonID != "alice2" || c.Confidence != 92 {
+		t.Errorf("candidate: %#v\n", c)
+	}
+	var buf bytes.Buffer
+	if err = report.WriteCSV(&buf); err != nil {
+		t.Errorf("WriteCSV failed: %s\n", err)
+	}
+	want := "person_id,group_id,match_person_id,match_group_id,confidence\nalice,g1,alice2,g2,92.00\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV: %q, want %q\n", buf.String(), want)
+	}
+}
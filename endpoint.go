@@ -0,0 +1,60 @@
+/*
+* File Name:	endpoint.go
+* Description:  接口定义, 具体接口由endpoints.json生成到endpoints_gen.go
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import "time"
+
+//go:generate go run gen.go
+
+//pathFamily 接口的路径族, 如api对应/youtu/api/
+type pathFamily string
+
+const (
+	familyAPI pathFamily = "api"
+)
+
+//timeoutClass 接口的超时类别, 上传多张图片的接口需要更长的超时
+type timeoutClass int
+
+const (
+	timeoutNormal timeoutClass = iota
+	timeoutUpload
+)
+
+func (tc timeoutClass) duration() time.Duration {
+	switch tc {
+	case timeoutUpload:
+		return 30 * time.Second
+	default:
+		return 5 * time.Second
+	}
+}
+
+//endpoint 一个接口的定义
+type endpoint struct {
+	family   pathFamily
+	timeout  timeoutClass
+	mutating bool //是否修改服务端数据, 如newperson, addface
+	billable bool //是否按调用计费
+}
+
+//lookupEndpoint 依次在内置和注册的接口中查找接口定义, 未知接口按api族和普通超时处理
+func lookupEndpoint(ifname string) endpoint {
+	if ep, ok := knownEndpoint(ifname); ok {
+		return ep
+	}
+	return endpoint{family: familyAPI, timeout: timeoutNormal}
+}
+
+//knownEndpoint 依次在内置和注册的接口中查找接口定义, 未知接口时ok为false
+func knownEndpoint(ifname string) (ep endpoint, ok bool) {
+	if ep, ok = endpoints[ifname]; ok {
+		return
+	}
+	return lookupRegistered(ifname)
+}
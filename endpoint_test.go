@@ -0,0 +1,49 @@
+/*
+* File Name:	endpoint_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+//TestEndpointsGenerated 检查endpoints_gen.go与endpoints.json一致, 不一致时需要执行go generate
+func TestEndpointsGenerated(t *testing.T) {
+	data, err := ioutil.ReadFile("endpoints.json")
+	if err != nil {
+		t.Errorf("ReadFile failed: %s\n", err)
+		return
+	}
+	var table struct {
+		Endpoints []struct {
+			Name   string `json:"name"`
+			Family string `json:"family"`
+		} `json:"endpoints"`
+	}
+	if err = json.Unmarshal(data, &table); err != nil {
+		t.Errorf("Unmarshal endpoints.json failed: %s\n", err)
+		return
+	}
+	if len(table.Endpoints) != len(endpoints) {
+		t.Errorf("endpoints.json has %d endpoints, endpoints_gen.go has %d, run go generate\n", len(table.Endpoints), len(endpoints))
+	}
+	for _, ep := range table.Endpoints {
+		got, ok := endpoints[ep.Name]
+		if !ok || string(got.family) != ep.Family {
+			t.Errorf("endpoint %s out of date, run go generate\n", ep.Name)
+		}
+	}
+}
+
+func TestInterfaceURL(t *testing.T) {
+	y := Init(as, WithHost("example.com"))
+	if got, want := y.interfaceURL("detectface"), "http://example.com/youtu/api/detectface"; got != want {
+		t.Errorf("interfaceURL: %s, want %s\n", got, want)
+	}
+}
@@ -0,0 +1,865 @@
+// Code generated by gen.go from endpoints.json; DO NOT EDIT.
+
+package youtu
+
+import "context"
+
+// endpoints 所有已知接口的定义
+var endpoints = map[string]endpoint{
+	"detectface":        {family: "api", timeout: timeoutNormal, billable: true},
+	"facecompare":       {family: "api", timeout: timeoutNormal, billable: true},
+	"faceverify":        {family: "api", timeout: timeoutNormal, billable: true},
+	"faceidentify":      {family: "api", timeout: timeoutNormal, billable: true},
+	"newperson":         {family: "api", timeout: timeoutNormal, mutating: true, billable: true},
+	"delperson":         {family: "api", timeout: timeoutNormal, mutating: true},
+	"addface":           {family: "api", timeout: timeoutUpload, mutating: true, billable: true},
+	"delface":           {family: "api", timeout: timeoutNormal, mutating: true},
+	"setinfo":           {family: "api", timeout: timeoutNormal, mutating: true},
+	"getinfo":           {family: "api", timeout: timeoutNormal},
+	"getgroupids":       {family: "api", timeout: timeoutNormal},
+	"getpersonids":      {family: "api", timeout: timeoutNormal},
+	"getfaceids":        {family: "api", timeout: timeoutNormal},
+	"getfaceinfo":       {family: "api", timeout: timeoutNormal},
+	"faceshape":         {family: "api", timeout: timeoutNormal, billable: true},
+	"multifaceidentify": {family: "api", timeout: timeoutNormal, billable: true},
+	"fuzzydetect":       {family: "imageapi", timeout: timeoutNormal, billable: true},
+	"fooddetect":        {family: "imageapi", timeout: timeoutNormal, billable: true},
+	"imagetag":          {family: "imageapi", timeout: timeoutNormal, billable: true},
+	"imageporn":         {family: "imageapi", timeout: timeoutNormal, billable: true},
+	"imageterrorism":    {family: "imageapi", timeout: timeoutNormal, billable: true},
+	"carclassify":       {family: "carapi", timeout: timeoutNormal, billable: true},
+	"idcardocr":         {family: "ocrapi", timeout: timeoutNormal, billable: true},
+	"namecardocr":       {family: "ocrapi", timeout: timeoutNormal, billable: true},
+	"generalocr":        {family: "ocrapi", timeout: timeoutNormal, billable: true},
+	"driverlicenseocr":  {family: "ocrapi", timeout: timeoutNormal, billable: true},
+	"bizlicenseocr":     {family: "ocrapi", timeout: timeoutNormal, billable: true},
+	"plateocr":          {family: "ocrapi", timeout: timeoutNormal, billable: true},
+	"creditcardocr":     {family: "ocrapi", timeout: timeoutNormal, billable: true},
+	"handwritingocr":    {family: "ocrapi", timeout: timeoutNormal, billable: true},
+	"livegetfour":       {family: "openliveapi", timeout: timeoutNormal},
+}
+
+type detectFaceReq struct {
+	AppID string     `json:"app_id"`         //App的 API ID
+	Image string     `json:"image"`          //base64编码的二进制图片数据
+	Mode  DetectMode `json:"mode,omitempty"` //检测模式 0/1 正常/大脸模式
+}
+
+func (r detectFaceReq) images() []string {
+	return []string{r.Image}
+}
+
+func (r detectFaceReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	if r.Image, err = fn(r.Image); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DetectFace 检测给定图片(Image)中的所有人脸(Face)的位置和相应的面部属性。
+// 位置包括(x, y, w, h)，面部属性包括性别(gender), 年龄(age),
+// 表情(expression), 眼镜(glass)和姿态(pitch，roll，yaw).
+func (y *Youtu) DetectFace(imageData string, mode DetectMode) (dfr DetectFaceRsp, err error) {
+	return y.DetectFaceCtx(context.Background(), imageData, mode)
+}
+
+// DetectFaceCtx 同DetectFace, ctx用于取消请求和设置截止时间
+func (y *Youtu) DetectFaceCtx(ctx context.Context, imageData string, mode DetectMode) (dfr DetectFaceRsp, err error) {
+	req := detectFaceReq{
+		AppID: y.appID(),
+		Image: imageData,
+		Mode:  mode,
+	}
+	err = y.interfaceRequest(ctx, "detectface", req, &dfr)
+	return
+}
+
+type faceCompareReq struct {
+	AppID  string `json:"app_id"`
+	ImageA string `json:"imageA"` //使用base64编码的二进制图片数据A
+	ImageB string `json:"imageB"` //使用base64编码的二进制图片数据B
+}
+
+func (r faceCompareReq) images() []string {
+	return []string{r.ImageA, r.ImageB}
+}
+
+func (r faceCompareReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	if r.ImageA, err = fn(r.ImageA); err != nil {
+		return nil, err
+	}
+	if r.ImageB, err = fn(r.ImageB); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FaceCompare 计算两个Face的相似性以及五官相似度
+func (y *Youtu) FaceCompare(imageA string, imageB string) (fcr FaceCompareRsp, err error) {
+	return y.FaceCompareCtx(context.Background(), imageA, imageB)
+}
+
+// FaceCompareCtx 同FaceCompare, ctx用于取消请求和设置截止时间
+func (y *Youtu) FaceCompareCtx(ctx context.Context, imageA string, imageB string) (fcr FaceCompareRsp, err error) {
+	req := faceCompareReq{
+		AppID:  y.appID(),
+		ImageA: imageA,
+		ImageB: imageB,
+	}
+	err = y.interfaceRequest(ctx, "facecompare", req, &fcr)
+	return
+}
+
+type faceVerifyReq struct {
+	AppID    string `json:"app_id"`    //App的 API ID
+	Image    string `json:"image"`     //使用base64编码的二进制图片数据
+	PersonID string `json:"person_id"` //待验证的Person
+}
+
+func (r faceVerifyReq) images() []string {
+	return []string{r.Image}
+}
+
+func (r faceVerifyReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	if r.Image, err = fn(r.Image); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FaceVerify 给定一个Face和一个Person，返回是否是同一个人的判断以及置信度。
+func (y *Youtu) FaceVerify(image string, personID string) (fvr FaceVerifyRsp, err error) {
+	return y.FaceVerifyCtx(context.Background(), image, personID)
+}
+
+// FaceVerifyCtx 同FaceVerify, ctx用于取消请求和设置截止时间
+func (y *Youtu) FaceVerifyCtx(ctx context.Context, image string, personID string) (fvr FaceVerifyRsp, err error) {
+	req := faceVerifyReq{
+		AppID:    y.appID(),
+		Image:    image,
+		PersonID: personID,
+	}
+	err = y.interfaceRequest(ctx, "faceverify", req, &fvr)
+	return
+}
+
+type faceIdentifyReq struct {
+	AppID   string `json:"app_id"`         //App的 API ID
+	GroupID string `json:"group_id"`       //候选人组id
+	Image   string `json:"image"`          //使用base64编码的二进制图片数据
+	TopN    int    `json:"topn,omitempty"` //返回的候选人个数, 为0时使用服务端的默认值
+}
+
+func (r faceIdentifyReq) images() []string {
+	return []string{r.Image}
+}
+
+func (r faceIdentifyReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	if r.Image, err = fn(r.Image); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FaceIdentify 对于一个待识别的人脸图片，在一个Group中识别出最相似的Person作为其身份返回
+func (y *Youtu) FaceIdentify(image string, groupID string) (fir FaceIdentifyRsp, err error) {
+	return y.FaceIdentifyCtx(context.Background(), image, groupID)
+}
+
+// FaceIdentifyCtx 同FaceIdentify, ctx用于取消请求和设置截止时间
+func (y *Youtu) FaceIdentifyCtx(ctx context.Context, image string, groupID string) (fir FaceIdentifyRsp, err error) {
+	req := faceIdentifyReq{
+		AppID:   y.appID(),
+		Image:   image,
+		GroupID: groupID,
+	}
+	err = y.interfaceRequest(ctx, "faceidentify", req, &fir)
+	return
+}
+
+// FaceIdentifyTopN 同FaceIdentify, 在Candidates中按置信度从高到低返回最多topn个候选人, 便于应用自行设置阈值
+func (y *Youtu) FaceIdentifyTopN(image string, groupID string, topn int) (fir FaceIdentifyRsp, err error) {
+	return y.FaceIdentifyTopNCtx(context.Background(), image, groupID, topn)
+}
+
+// FaceIdentifyTopNCtx 同FaceIdentifyTopN, ctx用于取消请求和设置截止时间
+func (y *Youtu) FaceIdentifyTopNCtx(ctx context.Context, image string, groupID string, topn int) (fir FaceIdentifyRsp, err error) {
+	req := faceIdentifyReq{
+		AppID:   y.appID(),
+		Image:   image,
+		GroupID: groupID,
+		TopN:    topn,
+	}
+	err = y.interfaceRequest(ctx, "faceidentify", req, &fir)
+	return
+}
+
+type newPersonReq struct {
+	AppID      string   `json:"app_id"`          //App的 API ID
+	Image      string   `json:"image,omitempty"` //使用base64编码的二进制图片数据
+	URL        string   `json:"url,omitempty"`   //图片的url, 与image二选一
+	PersonID   string   `json:"person_id"`
+	GroupIDs   []string `json:"group_ids"`             //加入到组的列表
+	PersonName string   `json:"person_name,omitempty"` //名字
+	Tag        string   `json:"tag,omitempty"`         //备注信息
+}
+
+func (r newPersonReq) images() []string {
+	return []string{r.Image}
+}
+
+func (r newPersonReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	if r.Image, err = fn(r.Image); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewPerson 创建一个Person，并将Person放置到group_ids指定的组当中
+func (y *Youtu) NewPerson(image string, personID string, groupIDs []string, personName string, tag string) (npr NewPersonRsp, err error) {
+	return y.NewPersonCtx(context.Background(), image, personID, groupIDs, personName, tag)
+}
+
+// NewPersonCtx 同NewPerson, ctx用于取消请求和设置截止时间
+func (y *Youtu) NewPersonCtx(ctx context.Context, image string, personID string, groupIDs []string, personName string, tag string) (npr NewPersonRsp, err error) {
+	req := newPersonReq{
+		AppID:      y.appID(),
+		Image:      image,
+		PersonID:   personID,
+		GroupIDs:   groupIDs,
+		PersonName: personName,
+		Tag:        tag,
+	}
+	err = y.interfaceRequest(ctx, "newperson", req, &npr)
+	return
+}
+
+// NewPersonURL 与NewPerson相同, 但人脸图片由url指定, 由服务端自行下载
+func (y *Youtu) NewPersonURL(url string, personID string, groupIDs []string, personName string, tag string) (npr NewPersonRsp, err error) {
+	return y.NewPersonURLCtx(context.Background(), url, personID, groupIDs, personName, tag)
+}
+
+// NewPersonURLCtx 同NewPersonURL, ctx用于取消请求和设置截止时间
+func (y *Youtu) NewPersonURLCtx(ctx context.Context, url string, personID string, groupIDs []string, personName string, tag string) (npr NewPersonRsp, err error) {
+	req := newPersonReq{
+		AppID:      y.appID(),
+		URL:        url,
+		PersonID:   personID,
+		GroupIDs:   groupIDs,
+		PersonName: personName,
+		Tag:        tag,
+	}
+	err = y.interfaceRequest(ctx, "newperson", req, &npr)
+	return
+}
+
+type delPersonReq struct {
+	AppID    string `json:"app_id"`
+	PersonID string `json:"person_id"` //待删除个体ID
+}
+
+// DelPerson 删除一个Person
+func (y *Youtu) DelPerson(personID string) (dpr DelPersonRsp, err error) {
+	return y.DelPersonCtx(context.Background(), personID)
+}
+
+// DelPersonCtx 同DelPerson, ctx用于取消请求和设置截止时间
+func (y *Youtu) DelPersonCtx(ctx context.Context, personID string) (dpr DelPersonRsp, err error) {
+	req := delPersonReq{
+		AppID:    y.appID(),
+		PersonID: personID,
+	}
+	err = y.interfaceRequest(ctx, "delperson", req, &dpr)
+	return
+}
+
+type addFaceReq struct {
+	AppID    string   `json:"app_id"`           //App的 API ID
+	PersonID string   `json:"person_id"`        //待增加人脸的个体id
+	Images   []string `json:"images,omitempty"` //base64编码的二进制图片数据构成的数组
+	URLs     []string `json:"urls,omitempty"`   //图片url构成的数组, 与images二选一
+	Tag      string   `json:"tag,omitempty"`    //备注信息
+}
+
+func (r addFaceReq) images() []string {
+	return r.Images
+}
+
+func (r addFaceReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	images := make([]string, len(r.Images))
+	for i, img := range r.Images {
+		if images[i], err = fn(img); err != nil {
+			return nil, err
+		}
+	}
+	r.Images = images
+	return r, nil
+}
+
+// AddFace 将一组Face加入到一个Person中。注意，一个Face只能被加入到一个Person中。
+// 一个Person最多允许包含10000个Face
+func (y *Youtu) AddFace(images []string, personID string, tag string) (afr AddFaceRsp, err error) {
+	return y.AddFaceCtx(context.Background(), images, personID, tag)
+}
+
+// AddFaceCtx 同AddFace, ctx用于取消请求和设置截止时间
+func (y *Youtu) AddFaceCtx(ctx context.Context, images []string, personID string, tag string) (afr AddFaceRsp, err error) {
+	req := addFaceReq{
+		AppID:    y.appID(),
+		Images:   images,
+		PersonID: personID,
+		Tag:      tag,
+	}
+	err = y.interfaceRequest(ctx, "addface", req, &afr)
+	return
+}
+
+// AddFaceURLs 与AddFace相同, 但人脸图片由url列表指定, 由服务端自行下载
+func (y *Youtu) AddFaceURLs(urls []string, personID string, tag string) (afr AddFaceRsp, err error) {
+	return y.AddFaceURLsCtx(context.Background(), urls, personID, tag)
+}
+
+// AddFaceURLsCtx 同AddFaceURLs, ctx用于取消请求和设置截止时间
+func (y *Youtu) AddFaceURLsCtx(ctx context.Context, urls []string, personID string, tag string) (afr AddFaceRsp, err error) {
+	req := addFaceReq{
+		AppID:    y.appID(),
+		URLs:     urls,
+		PersonID: personID,
+		Tag:      tag,
+	}
+	err = y.interfaceRequest(ctx, "addface", req, &afr)
+	return
+}
+
+type delFaceReq struct {
+	AppID    string   `json:"app_id"`    //App的 API ID
+	PersonID string   `json:"person_id"` //待删除人脸的person ID
+	FaceIDs  []string `json:"face_ids"`  //删除人脸id的列表
+}
+
+// DelFace 删除一个person下的face，包括特征，属性和face_id.
+func (y *Youtu) DelFace(personID string, faceIDs []string) (dfr DelFaceRsp, err error) {
+	return y.DelFaceCtx(context.Background(), personID, faceIDs)
+}
+
+// DelFaceCtx 同DelFace, ctx用于取消请求和设置截止时间
+func (y *Youtu) DelFaceCtx(ctx context.Context, personID string, faceIDs []string) (dfr DelFaceRsp, err error) {
+	req := delFaceReq{
+		AppID:    y.appID(),
+		PersonID: personID,
+		FaceIDs:  faceIDs,
+	}
+	err = y.interfaceRequest(ctx, "delface", req, &dfr)
+	return
+}
+
+type setInfoReq struct {
+	AppID      string `json:"app_id"` //App的 API ID
+	PersonID   string `json:"person_id"`
+	PersonName string `json:"person_name,omitempty"` //新的name
+	Tag        string `json:"tag,omitempty"`         //备注信息
+}
+
+// SetInfo 设置Person的name.
+func (y *Youtu) SetInfo(personID string, personName string, tag string) (sir SetInfoRsp, err error) {
+	return y.SetInfoCtx(context.Background(), personID, personName, tag)
+}
+
+// SetInfoCtx 同SetInfo, ctx用于取消请求和设置截止时间
+func (y *Youtu) SetInfoCtx(ctx context.Context, personID string, personName string, tag string) (sir SetInfoRsp, err error) {
+	req := setInfoReq{
+		AppID:      y.appID(),
+		PersonID:   personID,
+		PersonName: personName,
+		Tag:        tag,
+	}
+	err = y.interfaceRequest(ctx, "setinfo", req, &sir)
+	return
+}
+
+type getInfoReq struct {
+	AppID    string `json:"app_id"`    //App的 API ID
+	PersonID string `json:"person_id"` //待查询个体的ID
+}
+
+// GetInfo 获取一个Person的信息, 包括name, id, tag, 相关的face, 以及groups等信息。
+func (y *Youtu) GetInfo(personID string) (gir GetInfoRsp, err error) {
+	return y.GetInfoCtx(context.Background(), personID)
+}
+
+// GetInfoCtx 同GetInfo, ctx用于取消请求和设置截止时间
+func (y *Youtu) GetInfoCtx(ctx context.Context, personID string) (gir GetInfoRsp, err error) {
+	req := getInfoReq{
+		AppID:    y.appID(),
+		PersonID: personID,
+	}
+	err = y.interfaceRequest(ctx, "getinfo", req, &gir)
+	return
+}
+
+type getGroupIDsReq struct {
+	AppID string `json:"app_id"` //App的 API ID
+}
+
+// GetGroupIDs 获取一个appId下所有group列表
+func (y *Youtu) GetGroupIDs() (ggr GetGroupIDsRsp, err error) {
+	return y.GetGroupIDsCtx(context.Background())
+}
+
+// GetGroupIDsCtx 同GetGroupIDs, ctx用于取消请求和设置截止时间
+func (y *Youtu) GetGroupIDsCtx(ctx context.Context) (ggr GetGroupIDsRsp, err error) {
+	req := getGroupIDsReq{
+		AppID: y.appID(),
+	}
+	err = y.interfaceRequest(ctx, "getgroupids", req, &ggr)
+	return
+}
+
+type getPersonIDsReq struct {
+	AppID   string `json:"app_id"`   //App的 API ID
+	GroupID string `json:"group_id"` //组id
+}
+
+// GetPersonIDs 获取一个组Group中所有person列表
+func (y *Youtu) GetPersonIDs(groupID string) (gpr GetPersonIDsRsp, err error) {
+	return y.GetPersonIDsCtx(context.Background(), groupID)
+}
+
+// GetPersonIDsCtx 同GetPersonIDs, ctx用于取消请求和设置截止时间
+func (y *Youtu) GetPersonIDsCtx(ctx context.Context, groupID string) (gpr GetPersonIDsRsp, err error) {
+	req := getPersonIDsReq{
+		AppID:   y.appID(),
+		GroupID: groupID,
+	}
+	err = y.interfaceRequest(ctx, "getpersonids", req, &gpr)
+	return
+}
+
+type getFaceIDsReq struct {
+	AppID    string `json:"app_id"`    //App的 API ID
+	PersonID string `json:"person_id"` //个体id
+}
+
+// GetFaceIDs 获取一个组person中所有face列表
+func (y *Youtu) GetFaceIDs(personID string) (gfr GetFaceIDsRsp, err error) {
+	return y.GetFaceIDsCtx(context.Background(), personID)
+}
+
+// GetFaceIDsCtx 同GetFaceIDs, ctx用于取消请求和设置截止时间
+func (y *Youtu) GetFaceIDsCtx(ctx context.Context, personID string) (gfr GetFaceIDsRsp, err error) {
+	req := getFaceIDsReq{
+		AppID:    y.appID(),
+		PersonID: personID,
+	}
+	err = y.interfaceRequest(ctx, "getfaceids", req, &gfr)
+	return
+}
+
+type getFaceInfoReq struct {
+	AppID  string `json:"app_id"`  //App的 API ID
+	FaceID string `json:"face_id"` //人脸id
+}
+
+// GetFaceInfo 获取一个face的相关特征信息
+func (y *Youtu) GetFaceInfo(faceID string) (gfr GetFaceInfoRsp, err error) {
+	return y.GetFaceInfoCtx(context.Background(), faceID)
+}
+
+// GetFaceInfoCtx 同GetFaceInfo, ctx用于取消请求和设置截止时间
+func (y *Youtu) GetFaceInfoCtx(ctx context.Context, faceID string) (gfr GetFaceInfoRsp, err error) {
+	req := getFaceInfoReq{
+		AppID:  y.appID(),
+		FaceID: faceID,
+	}
+	err = y.interfaceRequest(ctx, "getfaceinfo", req, &gfr)
+	return
+}
+
+type faceShapeReq struct {
+	AppID string     `json:"app_id"`         //App的 API ID
+	Image string     `json:"image"`          //base64编码的二进制图片数据
+	Mode  DetectMode `json:"mode,omitempty"` //检测模式 0/1 正常/大脸模式
+}
+
+func (r faceShapeReq) images() []string {
+	return []string{r.Image}
+}
+
+func (r faceShapeReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	if r.Image, err = fn(r.Image); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FaceShape 对给定图片(Image)中的所有人脸进行五官定位, 返回脸型、眉毛、眼睛、鼻子和嘴巴的轮廓点,
+// 可以用于叠加渲染前的人脸对齐
+func (y *Youtu) FaceShape(imageData string, mode DetectMode) (fsr FaceShapeRsp, err error) {
+	return y.FaceShapeCtx(context.Background(), imageData, mode)
+}
+
+// FaceShapeCtx 同FaceShape, ctx用于取消请求和设置截止时间
+func (y *Youtu) FaceShapeCtx(ctx context.Context, imageData string, mode DetectMode) (fsr FaceShapeRsp, err error) {
+	req := faceShapeReq{
+		AppID: y.appID(),
+		Image: imageData,
+		Mode:  mode,
+	}
+	err = y.interfaceRequest(ctx, "faceshape", req, &fsr)
+	return
+}
+
+type multiFaceIdentifyReq struct {
+	AppID   string `json:"app_id"`         //App的 API ID
+	GroupID string `json:"group_id"`       //候选人组id
+	Image   string `json:"image"`          //使用base64编码的二进制图片数据
+	TopN    int    `json:"topn,omitempty"` //每个人脸返回的候选人个数, 为0时使用服务端的默认值
+}
+
+func (r multiFaceIdentifyReq) images() []string {
+	return []string{r.Image}
+}
+
+func (r multiFaceIdentifyReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	if r.Image, err = fn(r.Image); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// MultiFaceIdentify 检测图片(如合照)中的所有人脸, 并分别在一个Group中识别身份,
+// 每个人脸返回人脸框和最多topn个候选人
+func (y *Youtu) MultiFaceIdentify(image string, groupID string, topn int) (mfr MultiFaceIdentifyRsp, err error) {
+	return y.MultiFaceIdentifyCtx(context.Background(), image, groupID, topn)
+}
+
+// MultiFaceIdentifyCtx 同MultiFaceIdentify, ctx用于取消请求和设置截止时间
+func (y *Youtu) MultiFaceIdentifyCtx(ctx context.Context, image string, groupID string, topn int) (mfr MultiFaceIdentifyRsp, err error) {
+	req := multiFaceIdentifyReq{
+		AppID:   y.appID(),
+		Image:   image,
+		GroupID: groupID,
+		TopN:    topn,
+	}
+	err = y.interfaceRequest(ctx, "multifaceidentify", req, &mfr)
+	return
+}
+
+type imageReq struct {
+	AppID string `json:"app_id"` //App的 API ID
+	Image string `json:"image"`  //base64编码的二进制图片数据
+}
+
+func (r imageReq) images() []string {
+	return []string{r.Image}
+}
+
+func (r imageReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	if r.Image, err = fn(r.Image); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FuzzyDetect 判断图片是否模糊, 可以在AddFace之前拒绝模糊的注册照片
+func (y *Youtu) FuzzyDetect(imageData string) (fdr FuzzyDetectRsp, err error) {
+	return y.FuzzyDetectCtx(context.Background(), imageData)
+}
+
+// FuzzyDetectCtx 同FuzzyDetect, ctx用于取消请求和设置截止时间
+func (y *Youtu) FuzzyDetectCtx(ctx context.Context, imageData string) (fdr FuzzyDetectRsp, err error) {
+	req := imageReq{
+		AppID: y.appID(),
+		Image: imageData,
+	}
+	err = y.interfaceRequest(ctx, "fuzzydetect", req, &fdr)
+	return
+}
+
+// FoodDetect 判断图片是否为美食图片
+func (y *Youtu) FoodDetect(imageData string) (fdr FoodDetectRsp, err error) {
+	return y.FoodDetectCtx(context.Background(), imageData)
+}
+
+// FoodDetectCtx 同FoodDetect, ctx用于取消请求和设置截止时间
+func (y *Youtu) FoodDetectCtx(ctx context.Context, imageData string) (fdr FoodDetectRsp, err error) {
+	req := imageReq{
+		AppID: y.appID(),
+		Image: imageData,
+	}
+	err = y.interfaceRequest(ctx, "fooddetect", req, &fdr)
+	return
+}
+
+// ImageTag 识别图片中的物体和场景, 返回标签及其置信度
+func (y *Youtu) ImageTag(imageData string) (itr ImageTagRsp, err error) {
+	return y.ImageTagCtx(context.Background(), imageData)
+}
+
+// ImageTagCtx 同ImageTag, ctx用于取消请求和设置截止时间
+func (y *Youtu) ImageTagCtx(ctx context.Context, imageData string) (itr ImageTagRsp, err error) {
+	req := imageReq{
+		AppID: y.appID(),
+		Image: imageData,
+	}
+	err = y.interfaceRequest(ctx, "imagetag", req, &itr)
+	return
+}
+
+// ImagePorn 智能鉴黄, 返回图片为正常、性感和色情的置信度
+func (y *Youtu) ImagePorn(imageData string) (ipr ImagePornRsp, err error) {
+	return y.ImagePornCtx(context.Background(), imageData)
+}
+
+// ImagePornCtx 同ImagePorn, ctx用于取消请求和设置截止时间
+func (y *Youtu) ImagePornCtx(ctx context.Context, imageData string) (ipr ImagePornRsp, err error) {
+	req := imageReq{
+		AppID: y.appID(),
+		Image: imageData,
+	}
+	err = y.interfaceRequest(ctx, "imageporn", req, &ipr)
+	return
+}
+
+// ImageTerrorism 暴恐图片识别, 返回图片中暴恐元素(如刀、枪、血腥)的标签及其置信度
+func (y *Youtu) ImageTerrorism(imageData string) (itr ImageTerrorismRsp, err error) {
+	return y.ImageTerrorismCtx(context.Background(), imageData)
+}
+
+// ImageTerrorismCtx 同ImageTerrorism, ctx用于取消请求和设置截止时间
+func (y *Youtu) ImageTerrorismCtx(ctx context.Context, imageData string) (itr ImageTerrorismRsp, err error) {
+	req := imageReq{
+		AppID: y.appID(),
+		Image: imageData,
+	}
+	err = y.interfaceRequest(ctx, "imageterrorism", req, &itr)
+	return
+}
+
+// CarClassify 识别图片中车辆的品牌、型号和颜色, 返回按置信度排列的候选
+func (y *Youtu) CarClassify(imageData string) (ccr CarClassifyRsp, err error) {
+	return y.CarClassifyCtx(context.Background(), imageData)
+}
+
+// CarClassifyCtx 同CarClassify, ctx用于取消请求和设置截止时间
+func (y *Youtu) CarClassifyCtx(ctx context.Context, imageData string) (ccr CarClassifyRsp, err error) {
+	req := imageReq{
+		AppID: y.appID(),
+		Image: imageData,
+	}
+	err = y.interfaceRequest(ctx, "carclassify", req, &ccr)
+	return
+}
+
+type idcardOcrReq struct {
+	AppID    string     `json:"app_id"`    //App的 API ID
+	Image    string     `json:"image"`     //base64编码的二进制图片数据
+	CardType IDCardType `json:"card_type"` //0/1 正面/反面
+	OcrOptions
+}
+
+func (r idcardOcrReq) images() []string {
+	return []string{r.Image}
+}
+
+func (r idcardOcrReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	if r.Image, err = fn(r.Image); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// IdcardOcr 身份证OCR识别, cardType指定正面或反面. 正面返回姓名、性别、民族、出生日期、住址和身份证号, 反面返回签发机关和有效期
+func (y *Youtu) IdcardOcr(imageData string, cardType IDCardType, opts OcrOptions) (ior IdcardOcrRsp, err error) {
+	return y.IdcardOcrCtx(context.Background(), imageData, cardType, opts)
+}
+
+// IdcardOcrCtx 同IdcardOcr, ctx用于取消请求和设置截止时间
+func (y *Youtu) IdcardOcrCtx(ctx context.Context, imageData string, cardType IDCardType, opts OcrOptions) (ior IdcardOcrRsp, err error) {
+	req := idcardOcrReq{
+		AppID:      y.appID(),
+		Image:      imageData,
+		CardType:   cardType,
+		OcrOptions: opts,
+	}
+	err = y.interfaceRequest(ctx, "idcardocr", req, &ior)
+	return
+}
+
+type ocrReq struct {
+	AppID string `json:"app_id"` //App的 API ID
+	Image string `json:"image"`  //base64编码的二进制图片数据
+	OcrOptions
+}
+
+func (r ocrReq) images() []string {
+	return []string{r.Image}
+}
+
+func (r ocrReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	if r.Image, err = fn(r.Image); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NamecardOcr 名片OCR识别, 返回姓名、电话、邮箱、公司、职位、地址等字段及其位置
+func (y *Youtu) NamecardOcr(imageData string, opts OcrOptions) (nor NamecardOcrRsp, err error) {
+	return y.NamecardOcrCtx(context.Background(), imageData, opts)
+}
+
+// NamecardOcrCtx 同NamecardOcr, ctx用于取消请求和设置截止时间
+func (y *Youtu) NamecardOcrCtx(ctx context.Context, imageData string, opts OcrOptions) (nor NamecardOcrRsp, err error) {
+	req := ocrReq{
+		AppID:      y.appID(),
+		Image:      imageData,
+		OcrOptions: opts,
+	}
+	err = y.interfaceRequest(ctx, "namecardocr", req, &nor)
+	return
+}
+
+// GeneralOcr 通用印刷体OCR识别, 按行返回文本、置信度和文本框
+func (y *Youtu) GeneralOcr(imageData string, opts OcrOptions) (gor GeneralOcrRsp, err error) {
+	return y.GeneralOcrCtx(context.Background(), imageData, opts)
+}
+
+// GeneralOcrCtx 同GeneralOcr, ctx用于取消请求和设置截止时间
+func (y *Youtu) GeneralOcrCtx(ctx context.Context, imageData string, opts OcrOptions) (gor GeneralOcrRsp, err error) {
+	req := ocrReq{
+		AppID:      y.appID(),
+		Image:      imageData,
+		OcrOptions: opts,
+	}
+	err = y.interfaceRequest(ctx, "generalocr", req, &gor)
+	return
+}
+
+// BizLicenseOcr 营业执照OCR识别, 返回注册号、公司名称、地址等字段
+func (y *Youtu) BizLicenseOcr(imageData string, opts OcrOptions) (blr BizLicenseOcrRsp, err error) {
+	return y.BizLicenseOcrCtx(context.Background(), imageData, opts)
+}
+
+// BizLicenseOcrCtx 同BizLicenseOcr, ctx用于取消请求和设置截止时间
+func (y *Youtu) BizLicenseOcrCtx(ctx context.Context, imageData string, opts OcrOptions) (blr BizLicenseOcrRsp, err error) {
+	req := ocrReq{
+		AppID:      y.appID(),
+		Image:      imageData,
+		OcrOptions: opts,
+	}
+	err = y.interfaceRequest(ctx, "bizlicenseocr", req, &blr)
+	return
+}
+
+// PlateOcr 车牌OCR识别, 返回车牌号、车牌颜色和置信度
+func (y *Youtu) PlateOcr(imageData string, opts OcrOptions) (por PlateOcrRsp, err error) {
+	return y.PlateOcrCtx(context.Background(), imageData, opts)
+}
+
+// PlateOcrCtx 同PlateOcr, ctx用于取消请求和设置截止时间
+func (y *Youtu) PlateOcrCtx(ctx context.Context, imageData string, opts OcrOptions) (por PlateOcrRsp, err error) {
+	req := ocrReq{
+		AppID:      y.appID(),
+		Image:      imageData,
+		OcrOptions: opts,
+	}
+	err = y.interfaceRequest(ctx, "plateocr", req, &por)
+	return
+}
+
+// CreditCardOcr 银行卡OCR识别, 返回卡号、发卡行、卡类型和有效期
+func (y *Youtu) CreditCardOcr(imageData string, opts OcrOptions) (cor CreditCardOcrRsp, err error) {
+	return y.CreditCardOcrCtx(context.Background(), imageData, opts)
+}
+
+// CreditCardOcrCtx 同CreditCardOcr, ctx用于取消请求和设置截止时间
+func (y *Youtu) CreditCardOcrCtx(ctx context.Context, imageData string, opts OcrOptions) (cor CreditCardOcrRsp, err error) {
+	req := ocrReq{
+		AppID:      y.appID(),
+		Image:      imageData,
+		OcrOptions: opts,
+	}
+	err = y.interfaceRequest(ctx, "creditcardocr", req, &cor)
+	return
+}
+
+// HandwritingOcr 手写体OCR识别, 按行返回文本和位置
+func (y *Youtu) HandwritingOcr(imageData string, opts OcrOptions) (hor HandwritingOcrRsp, err error) {
+	return y.HandwritingOcrCtx(context.Background(), imageData, opts)
+}
+
+// HandwritingOcrCtx 同HandwritingOcr, ctx用于取消请求和设置截止时间
+func (y *Youtu) HandwritingOcrCtx(ctx context.Context, imageData string, opts OcrOptions) (hor HandwritingOcrRsp, err error) {
+	req := ocrReq{
+		AppID:      y.appID(),
+		Image:      imageData,
+		OcrOptions: opts,
+	}
+	err = y.interfaceRequest(ctx, "handwritingocr", req, &hor)
+	return
+}
+
+type driverLicenseOcrReq struct {
+	AppID string      `json:"app_id"` //App的 API ID
+	Image string      `json:"image"`  //base64编码的二进制图片数据
+	Type  LicenseType `json:"type"`   //0/1 行驶证/驾驶证
+	OcrOptions
+}
+
+func (r driverLicenseOcrReq) images() []string {
+	return []string{r.Image}
+}
+
+func (r driverLicenseOcrReq) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+	if r.Image, err = fn(r.Image); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DriverLicenseOcr 行驶证/驾驶证OCR识别, licenseType指定证件类型
+func (y *Youtu) DriverLicenseOcr(imageData string, licenseType LicenseType, opts OcrOptions) (dlr DriverLicenseOcrRsp, err error) {
+	return y.DriverLicenseOcrCtx(context.Background(), imageData, licenseType, opts)
+}
+
+// DriverLicenseOcrCtx 同DriverLicenseOcr, ctx用于取消请求和设置截止时间
+func (y *Youtu) DriverLicenseOcrCtx(ctx context.Context, imageData string, licenseType LicenseType, opts OcrOptions) (dlr DriverLicenseOcrRsp, err error) {
+	req := driverLicenseOcrReq{
+		AppID:      y.appID(),
+		Image:      imageData,
+		Type:       licenseType,
+		OcrOptions: opts,
+	}
+	err = y.interfaceRequest(ctx, "driverlicenseocr", req, &dlr)
+	return
+}
+
+type liveGetFourReq struct {
+	AppID string `json:"app_id"` //App的 API ID
+}
+
+// LiveGetFour 获取唇语活体检测的4位数字验证码, 用户录制读出验证码的视频后提交检测
+func (y *Youtu) LiveGetFour() (lgr LiveGetFourRsp, err error) {
+	return y.LiveGetFourCtx(context.Background())
+}
+
+// LiveGetFourCtx 同LiveGetFour, ctx用于取消请求和设置截止时间
+func (y *Youtu) LiveGetFourCtx(ctx context.Context) (lgr LiveGetFourRsp, err error) {
+	req := liveGetFourReq{
+		AppID: y.appID(),
+	}
+	err = y.interfaceRequest(ctx, "livegetfour", req, &lgr)
+	return
+}
@@ -0,0 +1,75 @@
+/*
+* File Name:	errors.go
+* Description:  接口错误
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+//APIError 接口返回的errorcode非0时的错误
+type APIError struct {
+	Interface string //接口名
+	Code      int    //返回状态码
+	Msg       string //返回错误消息
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s failed: %d %s", e.Interface, e.Code, e.Msg)
+}
+
+//checkCode errorcode非0时返回*APIError
+func checkCode(ifname string, code int, msg string) error {
+	if code == 0 {
+		return nil
+	}
+	return &APIError{Interface: ifname, Code: code, Msg: msg}
+}
+
+//maxErrorBody StatusError中保留的响应体的最大长度
+const maxErrorBody = 512
+
+//StatusError 服务端返回非2xx的HTTP状态(限流的429和503为*ThrottleError), 响应体通常不是JSON,
+//如网关返回的HTML错误页
+type StatusError struct {
+	Interface  string        //接口名
+	StatusCode int           //HTTP状态码
+	Body       string        //响应体的开头, 最多512字节
+	RequestID  string        //请求ID
+	ClockSkew  time.Duration //认证失败时签名时间与服务端Date头的差, 正值表示本地时钟偏快
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("%s failed: %d %s: %q%s", e.Interface, e.StatusCode, http.StatusText(e.StatusCode), e.Body, requestIDSuffix(e.RequestID))
+	if e.ClockSkew > MaxClockSkew || e.ClockSkew < -MaxClockSkew {
+		msg += fmt.Sprintf(" (local clock differs from server by %s, check the clock or use WithClockSkew)", e.ClockSkew.Round(time.Second))
+	}
+	return msg
+}
+
+//Auth 是否为认证失败(401或403), 通常是签名过期或凭证错误, 重试无效
+func (e *StatusError) Auth() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+//Temporary 是否为服务端错误(5xx), 可以稍后重试
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode >= http.StatusInternalServerError
+}
+
+//ResponseTooLargeError 响应体超过WithMaxResponseBytes设置的上限
+type ResponseTooLargeError struct {
+	Interface string //接口名
+	Limit     int64  //响应体的上限
+	RequestID string //请求ID
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%s failed: response body exceeds %d bytes%s", e.Interface, e.Limit, requestIDSuffix(e.RequestID))
+}
@@ -0,0 +1,91 @@
+/*
+* File Name:	estimate.go
+* Description:  批量任务的调用量估算
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+//DefaultEstimateLatency 估算时默认的单次请求耗时
+const DefaultEstimateLatency = 500 * time.Millisecond
+
+//Plan 批量任务计划, 接口名到调用次数的映射,
+//如Plan{"detectface": N, "faceidentify": M, "newperson": K}
+type Plan map[string]int
+
+//EndpointEstimate 单个接口的调用量
+type EndpointEstimate struct {
+	Interface string //接口名
+	Calls     int    //调用次数
+	Billable  bool   //是否按调用计费
+}
+
+//CostEstimate 批量任务的估算结果
+type CostEstimate struct {
+	Endpoints     []EndpointEstimate //按接口名排序
+	Calls         int                //总调用次数
+	BillableCalls int                //计费的调用次数
+	Duration      time.Duration      //在客户端调度限制下的预计运行时间
+}
+
+//Estimate 在运行前估算批量任务各接口的调用次数和预计运行时间.
+//运行时间按SetScheduler设置的并发数和每秒请求数上限计算, latency为单次请求耗时,
+//不大于0时使用DefaultEstimateLatency. 计划中有未知接口时返回错误
+func (y *Youtu) Estimate(plan Plan, latency time.Duration) (est CostEstimate, err error) {
+	if latency <= 0 {
+		latency = DefaultEstimateLatency
+	}
+	for ifname, calls := range plan {
+		if calls < 0 {
+			err = fmt.Errorf("estimate %s: negative calls %d", ifname, calls)
+			return
+		}
+		ep, ok := knownEndpoint(ifname)
+		if !ok {
+			err = fmt.Errorf("estimate %s: unknown endpoint", ifname)
+			return
+		}
+		est.Endpoints = append(est.Endpoints, EndpointEstimate{Interface: ifname, Calls: calls, Billable: ep.billable})
+		est.Calls += calls
+		if ep.billable {
+			est.BillableCalls += calls
+		}
+	}
+	sort.Slice(est.Endpoints, func(i, j int) bool {
+		return est.Endpoints[i].Interface < est.Endpoints[j].Interface
+	})
+	est.Duration = y.scheduler.projectDuration(est.Calls, latency)
+	return
+}
+
+//projectDuration 估算n个耗时均为latency的请求在调度限制下的运行时间, s为nil时不限制
+func (s *Scheduler) projectDuration(n int, latency time.Duration) time.Duration {
+	if n == 0 {
+		return 0
+	}
+	if s == nil {
+		return latency
+	}
+	s.mu.Lock()
+	limit, burst, interval := s.limitLocked(), s.burst, s.interval
+	s.mu.Unlock()
+	//受并发数限制时分批进行, 每批耗时latency. 自适应调整时按当前的上限
+	d := latency
+	if limit > 0 {
+		d = time.Duration((n+limit-1)/limit) * latency
+	}
+	//受每秒请求数限制时, 前burst个请求立即发出, 最后一个请求在(n-burst)个间隔后发出
+	if n > burst {
+		if byRate := time.Duration(n-burst)*interval + latency; byRate > d {
+			d = byRate
+		}
+	}
+	return d
+}
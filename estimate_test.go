@@ -0,0 +1,63 @@
+/*
+* File Name:	estimate_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimate(t *testing.T) {
+	y := Init(as, WithHost("localhost"))
+	plan := Plan{"detectface": 1000, "faceidentify": 500, "newperson": 20, "getinfo": 30}
+	est, err := y.Estimate(plan, 100*time.Millisecond)
+	if err != nil {
+		t.Errorf("Estimate failed: %s\n", err)
+		return
+	}
+	if est.Calls != 1550 || est.BillableCalls != 1520 {
+		t.Errorf("calls: %d billable: %d, want 1550 1520\n", est.Calls, est.BillableCalls)
+	}
+	if len(est.Endpoints) != 4 || est.Endpoints[0].Interface != "detectface" || est.Endpoints[1].Billable != true || est.Endpoints[1].Interface != "faceidentify" || est.Endpoints[2].Billable {
+		t.Errorf("endpoints: %+v\n", est.Endpoints)
+	}
+	if est.Duration != 100*time.Millisecond {
+		t.Errorf("unscheduled duration: %s\n", est.Duration)
+	}
+
+	y.SetScheduler(NewScheduler(10, 0))
+	if est, _ = y.Estimate(plan, 100*time.Millisecond); est.Duration != 155*100*time.Millisecond {
+		t.Errorf("concurrency limited duration: %s\n", est.Duration)
+	}
+	//自适应调整后按当前的上限估算
+	s := NewScheduler(10, 0)
+	s.SetAdaptive(2)
+	s.feedback(true)
+	y.SetScheduler(s)
+	if est, _ = y.Estimate(plan, 100*time.Millisecond); est.Duration != 310*100*time.Millisecond {
+		t.Errorf("adaptive limited duration: %s, limit %d\n", est.Duration, s.Limit())
+	}
+	y.SetScheduler(NewScheduler(10, 5))
+	if est, _ = y.Estimate(plan, 100*time.Millisecond); est.Duration != 1549*200*time.Millisecond+100*time.Millisecond {
+		t.Errorf("rate limited duration: %s\n", est.Duration)
+	}
+	y.scheduler.SetBurst(50)
+	if est, _ = y.Estimate(plan, 100*time.Millisecond); est.Duration != 1500*200*time.Millisecond+100*time.Millisecond {
+		t.Errorf("burst duration: %s\n", est.Duration)
+	}
+}
+
+func TestEstimateUnknown(t *testing.T) {
+	y := Init(as, WithHost("localhost"))
+	if _, err := y.Estimate(Plan{"nosuchapi": 1}, 0); err == nil {
+		t.Errorf("unknown endpoint accepted\n")
+	}
+	if _, err := y.Estimate(Plan{"detectface": -1}, 0); err == nil {
+		t.Errorf("negative calls accepted\n")
+	}
+}
@@ -0,0 +1,102 @@
+/*
+* File Name:	fusion.go
+* Description:  得分归一化与融合
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import "math"
+
+//FusionMethod 多个得分的融合方法
+type FusionMethod int
+
+const (
+	//FuseMax 取最高得分, 任一信号足够强即可, 适合多帧中挑最好的一帧
+	FuseMax FusionMethod = iota
+	//FuseMean 按权重取加权平均
+	FuseMean
+	//FuseBayes 将得分视为独立证据下同一人的概率, 按权重累加对数几率(先验为0.5).
+	//多个一致的中等得分会得到比平均值更高的结果, 相互矛盾的得分会相互抵消
+	FuseBayes
+)
+
+//fuseEpsilon FuseBayes中得分的截断, 避免0和1的对数几率为无穷
+const fuseEpsilon = 1e-6
+
+//Signal 参与融合的一个得分
+type Signal struct {
+	Score  float64 //归一化的得分[0~1]
+	Weight float64 //权重, 不大于0时为1
+}
+
+//NormalizeConfidence 将识别或验证接口返回的置信度[0~100]映射到[0~1]
+func NormalizeConfidence(c float32) float64 {
+	s := float64(c) / 100
+	if s < 0 {
+		return 0
+	}
+	if s > 1 {
+		return 1
+	}
+	return s
+}
+
+//IdentifySignal 由识别结果生成信号
+func IdentifySignal(fir FaceIdentifyRsp, weight float64) Signal {
+	return Signal{Score: NormalizeConfidence(fir.Confidence), Weight: weight}
+}
+
+//VerifySignal 由验证结果生成信号
+func VerifySignal(fvr FaceVerifyRsp, weight float64) Signal {
+	return Signal{Score: NormalizeConfidence(fvr.Confidence), Weight: weight}
+}
+
+//CompareSignal 由对比结果的相似度生成信号
+func CompareSignal(fcr FaceCompareRsp, weight float64) Signal {
+	return Signal{Score: NormalizeConfidence(fcr.Similarity), Weight: weight}
+}
+
+//Fuse 按method将多个信号融合为一个得分[0~1], 没有信号时返回0
+func Fuse(method FusionMethod, signals ...Signal) float64 {
+	if len(signals) == 0 {
+		return 0
+	}
+	switch method {
+	case FuseMean:
+		var sum, wsum float64
+		for _, s := range signals {
+			w := s.weight()
+			sum += w * clampScore(s.Score)
+			wsum += w
+		}
+		return sum / wsum
+	case FuseBayes:
+		var logit float64
+		for _, s := range signals {
+			p := math.Min(math.Max(clampScore(s.Score), fuseEpsilon), 1-fuseEpsilon)
+			logit += s.weight() * math.Log(p/(1-p))
+		}
+		return 1 / (1 + math.Exp(-logit))
+	default:
+		var max float64
+		for _, s := range signals {
+			if v := clampScore(s.Score); v > max {
+				max = v
+			}
+		}
+		return max
+	}
+}
+
+func (s Signal) weight() float64 {
+	if s.Weight <= 0 {
+		return 1
+	}
+	return s.Weight
+}
+
+func clampScore(v float64) float64 {
+	return math.Min(math.Max(v, 0), 1)
+}
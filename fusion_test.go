@@ -0,0 +1,47 @@
+/*
+* File Name:	fusion_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFuse(t *testing.T) {
+	frames := []Signal{
+		CompareSignal(FaceCompareRsp{Similarity: 70}, 0),
+		CompareSignal(FaceCompareRsp{Similarity: 80}, 0),
+		IdentifySignal(FaceIdentifyRsp{Confidence: 90}, 2),
+	}
+	tests := []struct {
+		method FusionMethod
+		want   float64
+	}{
+		{FuseMax, 0.9},
+		{FuseMean, (0.7 + 0.8 + 2*0.9) / 4},
+		//logit = ln(0.7/0.3) + ln(0.8/0.2) + 2*ln(0.9/0.1)
+		{FuseBayes, 1 / (1 + 1/(0.7/0.3*0.8/0.2*81))},
+	}
+	for _, tt := range tests {
+		if got := Fuse(tt.method, frames...); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Fuse(%d) = %v, want %v\n", tt.method, got, tt.want)
+		}
+	}
+	if got := Fuse(FuseBayes, Signal{Score: 0.8}, Signal{Score: 0.2}); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("contradicting signals: %v, want 0.5\n", got)
+	}
+	if got := Fuse(FuseBayes, Signal{Score: 1}); got <= 0.99 || got > 1 {
+		t.Errorf("certain signal: %v\n", got)
+	}
+	if got := Fuse(FuseMean); got != 0 {
+		t.Errorf("no signals: %v\n", got)
+	}
+	if got := NormalizeConfidence(120); got != 1 {
+		t.Errorf("NormalizeConfidence(120) = %v\n", got)
+	}
+}
@@ -0,0 +1,221 @@
+//go:build ignore
+// +build ignore
+
+/*
+* File Name:	gen.go
+* Description:  根据endpoints.json生成接口的请求结构和方法
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+//gen 读取endpoints.json, 生成endpoints_gen.go.
+//新增接口时只需在endpoints.json中添加定义, 在youtu.go中添加返回结构, 然后执行go generate
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+)
+
+type field struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Image   bool   `json:"image"` //是否为base64编码的图片数据, 用于上传前的检查
+	Embed   bool   `json:"embed"` //是否为嵌入的选项结构, 如OcrOptions, 其字段直接编码到请求中
+	JSON    string `json:"json"`
+	Comment string `json:"comment"`
+}
+
+type arg struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Field string `json:"field"`
+}
+
+type method struct {
+	Name     string   `json:"name"`
+	Endpoint string   `json:"endpoint"`
+	Response string   `json:"response"`
+	Result   string   `json:"result"`
+	Doc      []string `json:"doc"`
+	Args     []arg    `json:"args"`
+}
+
+type request struct {
+	Type    string   `json:"type"`
+	Fields  []field  `json:"fields"`
+	Methods []method `json:"methods"`
+}
+
+//ImagesExpr 返回收集请求中所有图片数据的表达式, 没有图片时返回空
+func (r request) ImagesExpr() string {
+	var single, multi []string
+	for _, f := range r.Fields {
+		switch {
+		case !f.Image:
+		case f.Type == "[]string":
+			multi = append(multi, "r."+f.Name)
+		default:
+			single = append(single, "r."+f.Name)
+		}
+	}
+	if len(single) == 0 && len(multi) == 0 {
+		return ""
+	}
+	expr := "[]string{" + strings.Join(single, ", ") + "}"
+	if len(single) == 0 && len(multi) == 1 {
+		return multi[0]
+	}
+	for _, m := range multi {
+		expr = "append(" + expr + ", " + m + "...)"
+	}
+	return expr
+}
+
+//MapImagesBody 返回对请求中每个图片数据调用fn的语句, 没有图片时返回空.
+//[]string类型的字段复制后再修改, 不影响调用方的切片
+func (r request) MapImagesBody() string {
+	var b strings.Builder
+	for _, f := range r.Fields {
+		switch {
+		case !f.Image:
+		case f.Type == "[]string":
+			fmt.Fprintf(&b, "\t%s := make([]string, len(r.%s))\n", strings.ToLower(f.Name), f.Name)
+			fmt.Fprintf(&b, "\tfor i, img := range r.%s {\n", f.Name)
+			fmt.Fprintf(&b, "\t\tif %s[i], err = fn(img); err != nil {\n\t\t\treturn nil, err\n\t\t}\n\t}\n", strings.ToLower(f.Name))
+			fmt.Fprintf(&b, "\tr.%s = %s\n", f.Name, strings.ToLower(f.Name))
+		default:
+			fmt.Fprintf(&b, "\tif r.%s, err = fn(r.%s); err != nil {\n\t\treturn nil, err\n\t}\n", f.Name, f.Name)
+		}
+	}
+	return b.String()
+}
+
+type endpoint struct {
+	Name     string `json:"name"`
+	Family   string `json:"family"`
+	Timeout  string `json:"timeout"`
+	Mutating bool   `json:"mutating"` //是否修改服务端数据
+	Billable bool   `json:"billable"` //是否按调用计费
+}
+
+type table struct {
+	Endpoints []endpoint `json:"endpoints"`
+	Requests  []request  `json:"requests"`
+}
+
+var funcs = template.FuncMap{
+	"title": strings.Title,
+	"params": func(args []arg) string {
+		ps := make([]string, len(args))
+		for i, a := range args {
+			ps[i] = a.Name + " " + a.Type
+		}
+		return strings.Join(ps, ", ")
+	},
+	"names": func(args []arg) string {
+		ns := make([]string, len(args))
+		for i, a := range args {
+			ns[i] = ", " + a.Name
+		}
+		return strings.Join(ns, "")
+	},
+}
+
+var tmpl = template.Must(template.New("gen").Funcs(funcs).Parse(`// Code generated by gen.go from endpoints.json; DO NOT EDIT.
+
+package youtu
+
+import "context"
+
+//endpoints 所有已知接口的定义
+var endpoints = map[string]endpoint{
+{{- range .Endpoints}}
+	"{{.Name}}": {family: "{{.Family}}", timeout: timeout{{title .Timeout}}{{if .Mutating}}, mutating: true{{end}}{{if .Billable}}, billable: true{{end}}},
+{{- end}}
+}
+{{range $r := .Requests}}
+type {{.Type}} struct {
+{{- range .Fields}}
+{{- if .Embed}}
+	{{.Type}}{{if .Comment}} //{{.Comment}}{{end}}
+{{- else}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSON}}"` + "`" + `{{if .Comment}} //{{.Comment}}{{end}}
+{{- end}}
+{{- end}}
+}
+{{with .ImagesExpr}}
+func (r {{$r.Type}}) images() []string {
+	return {{.}}
+}
+{{end}}
+{{- with .MapImagesBody}}
+func (r {{$r.Type}}) mapImages(fn func(string) (string, error)) (interface{}, error) {
+	var err error
+{{.}}	return r, nil
+}
+{{end}}
+{{- range $m := .Methods}}
+{{range $i, $d := .Doc}}//{{if eq $i 0}}{{$m.Name}} {{end}}{{$d}}
+{{end -}}
+func (y *Youtu) {{.Name}}({{params .Args}}) ({{.Result}} {{.Response}}, err error) {
+	return y.{{.Name}}Ctx(context.Background(){{names .Args}})
+}
+
+//{{.Name}}Ctx 同{{.Name}}, ctx用于取消请求和设置截止时间
+func (y *Youtu) {{.Name}}Ctx(ctx context.Context{{range .Args}}, {{.Name}} {{.Type}}{{end}}) ({{.Result}} {{.Response}}, err error) {
+	req := {{$r.Type}}{
+		AppID: y.appID(),
+{{- range .Args}}
+		{{.Field}}: {{.Name}},
+{{- end}}
+	}
+	err = y.interfaceRequest(ctx, "{{.Endpoint}}", req, &{{.Result}})
+	return
+}
+{{end}}
+{{- end}}`))
+
+func main() {
+	data, err := ioutil.ReadFile("endpoints.json")
+	if err != nil {
+		fail(err)
+	}
+	var t table
+	if err = json.Unmarshal(data, &t); err != nil {
+		fail(err)
+	}
+	known := make(map[string]bool, len(t.Endpoints))
+	for _, ep := range t.Endpoints {
+		known[ep.Name] = true
+	}
+	for _, req := range t.Requests {
+		for _, m := range req.Methods {
+			if !known[m.Endpoint] {
+				fail(fmt.Errorf("method %s: unknown endpoint %s", m.Name, m.Endpoint))
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, t); err != nil {
+		fail(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fail(fmt.Errorf("format generated code: %s\n%s", err, buf.Bytes()))
+	}
+	if err = ioutil.WriteFile("endpoints_gen.go", src, 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "gen: %s\n", err)
+	os.Exit(1)
+}
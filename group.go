@@ -0,0 +1,67 @@
+/*
+* File Name:	group.go
+* Description:  组的清理
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import "context"
+
+//EmptyGroupOptions 清空组的选项
+type EmptyGroupOptions struct {
+	DeleteShared bool //同时删除还属于其它组的个体, 这些个体会从所有组中删除
+	DryRun       bool //只返回计划删除的个体, 不做任何修改
+}
+
+//EmptyGroupResult 清空组的结果
+type EmptyGroupResult struct {
+	Deleted []string //已删除(DryRun时为计划删除)的个体
+	Shared  []string //还属于其它组而保留的个体
+}
+
+//EmptyGroup 删除组groupID中的个体. 接口没有删除或重命名组的功能, 组在没有个体后自动消失,
+//因此清空组即删除组; 重命名需要以新的组重新创建个体.
+//默认只删除仅属于该组的个体, 保留的个体记录在Shared中, 此时组不会消失.
+//任一步骤失败时立即返回, 已删除的个体记录在EmptyGroupResult中
+func (y *Youtu) EmptyGroup(groupID string, opts EmptyGroupOptions) (res EmptyGroupResult, err error) {
+	return y.EmptyGroupCtx(context.Background(), groupID, opts)
+}
+
+//EmptyGroupCtx 同EmptyGroup, ctx用于取消请求和设置截止时间
+func (y *Youtu) EmptyGroupCtx(ctx context.Context, groupID string, opts EmptyGroupOptions) (res EmptyGroupResult, err error) {
+	gpr, err := y.GetPersonIDsCtx(ctx, groupID)
+	if err != nil {
+		return
+	}
+	if err = checkCode("getpersonids", int(gpr.ErrorCode), gpr.ErrorMsg); err != nil {
+		return
+	}
+	for _, personID := range gpr.PersonIDs {
+		if !opts.DeleteShared {
+			gir, err := y.GetInfoCtx(ctx, personID)
+			if err != nil {
+				return res, err
+			}
+			if err = checkCode("getinfo", gir.ErrorCode, gir.ErrorMsg); err != nil {
+				return res, err
+			}
+			if len(gir.GroupIDs) > 1 {
+				res.Shared = append(res.Shared, personID)
+				continue
+			}
+		}
+		if !opts.DryRun {
+			dpr, err := y.DelPersonCtx(ctx, personID)
+			if err != nil {
+				return res, err
+			}
+			if err = checkCode("delperson", dpr.ErrorCode, dpr.ErrorMsg); err != nil {
+				return res, err
+			}
+		}
+		res.Deleted = append(res.Deleted, personID)
+	}
+	return
+}
@@ -0,0 +1,50 @@
+/*
+* File Name:	group_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestEmptyGroup(t *testing.T) {
+	var deleted []string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		switch r.URL.Path[len("/youtu/api/"):] {
+		case "getpersonids":
+			fmt.Fprint(w, `{"person_ids":["alice","bob","carol"],"errorcode":0}`)
+		case "getinfo":
+			if req["person_id"] == "bob" {
+				fmt.Fprint(w, `{"person_id":"bob","group_ids":["test","staff"],"errorcode":0}`)
+				return
+			}
+			fmt.Fprintf(w, `{"person_id":%q,"group_ids":["test"],"errorcode":0}`, req["person_id"])
+		case "delperson":
+			deleted = append(deleted, req["person_id"].(string))
+			fmt.Fprint(w, `{"deleted":1,"errorcode":0}`)
+		}
+	})
+	defer srv.Close()
+
+	res, err := y.EmptyGroup("test", EmptyGroupOptions{DryRun: true})
+	if err != nil || !reflect.DeepEqual(res.Deleted, []string{"alice", "carol"}) || !reflect.DeepEqual(res.Shared, []string{"bob"}) || len(deleted) > 0 {
+		t.Errorf("dry run: %+v, deleted %v, %v\n", res, deleted, err)
+	}
+	if res, err = y.EmptyGroup("test", EmptyGroupOptions{}); err != nil || !reflect.DeepEqual(deleted, []string{"alice", "carol"}) {
+		t.Errorf("EmptyGroup: %+v, deleted %v, %v\n", res, deleted, err)
+	}
+	deleted = nil
+	if res, err = y.EmptyGroup("test", EmptyGroupOptions{DeleteShared: true}); err != nil || len(deleted) != 3 || len(res.Shared) != 0 {
+		t.Errorf("EmptyGroup with DeleteShared: %+v, deleted %v, %v\n", res, deleted, err)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+* File Name:	gzip.go
+* Description:  请求和响应的gzip压缩
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+)
+
+//DefaultGzipMinSize 请求压缩的默认阈值
+const DefaultGzipMinSize = 1024
+
+//WithRequestGzip 以gzip压缩不小于minSize字节的请求体(如包含base64图片的AddFace请求),
+//需要服务端支持Content-Encoding: gzip. minSize不大于0时使用DefaultGzipMinSize.
+//响应总是接受gzip压缩, 不需要设置
+func WithRequestGzip(minSize int) Option {
+	return func(y *Youtu) {
+		if minSize <= 0 {
+			minSize = DefaultGzipMinSize
+		}
+		y.gzipMinSize = minSize
+	}
+}
+
+//requestBody 返回请求体, 需要压缩时返回压缩后的数据, gzipped为true
+func (y *Youtu) requestBody(payload []byte) (body []byte, gzipped bool, err error) {
+	if y.gzipMinSize <= 0 || len(payload) < y.gzipMinSize {
+		return payload, false, nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err = zw.Write(payload); err != nil {
+		return
+	}
+	if err = zw.Close(); err != nil {
+		return
+	}
+	return buf.Bytes(), true, nil
+}
+
+//responseBody 返回解压后的响应体. 请求中显式设置了Accept-Encoding, http.Transport不会自动解压
+func responseBody(resp *http.Response) (io.Reader, error) {
+	if resp.Header.Get("Content-Encoding") != "gzip" || resp.Uncompressed {
+		return resp.Body, nil
+	}
+	return gzip.NewReader(resp.Body)
+}
@@ -0,0 +1,107 @@
+/*
+* File Name:	gzip_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGzip(t *testing.T) {
+	var encodings []string
+	var payload string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body = zr
+		}
+		data, _ := ioutil.ReadAll(body)
+		payload = string(data)
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Write([]byte(`{"errorcode":0}`))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`{"errorcode":0,"group_ids":["g"]}`))
+		zw.Close()
+	})
+	defer srv.Close()
+	WithRequestGzip(0)(y)
+
+	//小的请求不压缩, 响应总是解压
+	ggr, err := y.GetGroupIDs()
+	if err != nil || len(ggr.GroupIDs) != 1 {
+		t.Errorf("GetGroupIDs: %+v, %v\n", ggr, err)
+	}
+	image := strings.Repeat("QUJD", 1000)
+	if _, err = y.DetectFace(image, DetectModeNormal); err != nil {
+		t.Errorf("DetectFace failed: %s\n", err)
+	}
+	if len(encodings) != 2 || encodings[0] != "" || encodings[1] != "gzip" || !strings.Contains(payload, image) {
+		t.Errorf("request encodings %q, payload decoded %t\n", encodings, strings.Contains(payload, image))
+	}
+}
+
+//replayTransport 在发送前通过GetBody重新读取请求体, 模拟transport重发请求
+type replayTransport struct {
+	bodies [][]byte
+}
+
+func (rt *replayTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.GetBody == nil {
+		return nil, errors.New("request body not rewindable")
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	data, _ := ioutil.ReadAll(body)
+	rt.bodies = append(rt.bodies, data)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestRewindableBody(t *testing.T) {
+	var sent [][]byte
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		sent = append(sent, data)
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+	rt := &replayTransport{}
+	WithHTTPClient(&http.Client{Transport: rt})(y)
+	WithRequestGzip(0)(y)
+
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs failed: %s\n", err)
+	}
+	if _, err := y.DetectFace(strings.Repeat("QUJD", 1000), DetectModeNormal); err != nil {
+		t.Errorf("DetectFace failed: %s\n", err)
+	}
+	if len(rt.bodies) != 2 || len(sent) != 2 {
+		t.Fatalf("replayed %d bodies, sent %d, want 2\n", len(rt.bodies), len(sent))
+	}
+	for i := range sent {
+		if !bytes.Equal(rt.bodies[i], sent[i]) {
+			t.Errorf("request %d: replayed body differs from sent body\n", i)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+/*
+* File Name:	hedge.go
+* Description:  对冲请求
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"time"
+)
+
+//WithHedging 对已知的不修改数据的接口(如FaceIdentify)发出对冲请求: 请求发出delay后仍未返回时,
+//向host再发出一个相同的请求, 采用先成功返回的结果, 降低尾部延迟. host为空时发往同一服务地址.
+//对冲请求会增加调用次数, 设置了调度器时对冲请求同样占用名额和令牌, 没有空闲的名额或令牌时不对冲. 对冲请求不调用ctx中的httptrace.ClientTrace; 设置了ContextWithTimings时,
+//两个请求各自记录耗时, Timings中为采用的结果的耗时
+func WithHedging(delay time.Duration, host string) Option {
+	return func(y *Youtu) {
+		y.hedgeDelay, y.hedgeHost = delay, host
+	}
+}
+
+//hedging 接口是否发出对冲请求. 未注册的接口可能修改数据, 不对冲
+func (y *Youtu) hedging(ifname string) bool {
+	ep, ok := knownEndpoint(ifname)
+	return y.hedgeDelay > 0 && ok && !ep.mutating
+}
+
+type hedgeResult struct {
+	body    []byte
+	call    *Call
+	timings *Timings
+	err     error
+}
+
+//hedgeContext 返回对冲中一个请求的ctx. 两个请求并发进行, 不能共用ctx中的trace:
+//设置了ContextWithTimings时屏蔽ctx中的trace, 耗时记录到返回的Timings; 对冲请求总是屏蔽trace
+func hedgeContext(ctx context.Context, hedged bool) (context.Context, *Timings) {
+	if _, ok := ctx.Value(timingsKey{}).(*Timings); !ok {
+		if hedged {
+			return noTraceContext{ctx}, nil
+		}
+		return ctx, nil
+	}
+	t := new(Timings)
+	return ContextWithTimings(noTraceContext{ctx}, t), t
+}
+
+//hedge 发出请求, 超过对冲延迟未返回时向对冲地址发出相同的请求, 返回先成功的结果, 都失败时返回后失败的
+func (y *Youtu) hedge(ctx context.Context, url string, call *Call, timeout time.Duration) ([]byte, *Call, error) {
+	timings, _ := ctx.Value(timingsKey{}).(*Timings)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan hedgeResult, 2)
+	launch := func(url string, call *Call, hedged bool, release func()) {
+		ctx, t := hedgeContext(ctx, hedged)
+		go func() {
+			if release != nil {
+				defer release()
+			}
+			body, err := y.roundTrip(ctx, url, call, timeout)
+			results <- hedgeResult{body, call, t, err}
+		}()
+	}
+	//第一个请求进行中会写入call, 对冲请求的副本在发出前复制
+	hc := *call
+	hc.Header = call.Header.Clone()
+	hc.decode = decodeTarget(call.decode)
+	launch(url, call, false, nil)
+	timer := time.NewTimer(y.hedgeDelay)
+	defer timer.Stop()
+	pending, hedged := 1, false
+	for {
+		select {
+		case <-timer.C:
+			if !hedged {
+				hedged = true
+				//第一个请求已占用调度器的名额, 对冲请求另取名额和令牌, 不足时不对冲
+				var release func()
+				if y.scheduler != nil {
+					var ok bool
+					if release, ok = y.scheduler.tryAcquire(); !ok {
+						continue
+					}
+				}
+				pending++
+				launch(y.hedgeURL(hc.Interface), &hc, true, release)
+			}
+		case r := <-results:
+			//第一个请求在对冲前失败时不再对冲
+			if pending--; r.err == nil || pending == 0 {
+				if timings != nil && r.timings != nil {
+					*timings = *r.timings
+				}
+				return r.body, r.call, r.err
+			}
+		}
+	}
+}
+
+//hedgeURL 返回对冲请求的地址
+func (y *Youtu) hedgeURL(ifname string) string {
+	host := y.hedgeHost
+	if host == "" {
+		host = y.host
+	}
+	return y.interfaceURLAt(host, ifname)
+}
@@ -0,0 +1,191 @@
+/*
+* File Name:	hedge_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHedging(t *testing.T) {
+	unblock := make(chan struct{})
+	var slowCalls, fastCalls int32
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&slowCalls, 1)
+		ioutil.ReadAll(r.Body)
+		select {
+		case <-unblock:
+		case <-r.Context().Done():
+		}
+		w.Write([]byte(`{"errorcode":0,"group_ids":["slow"]}`))
+	}))
+	defer slow.Close()
+	defer close(unblock)
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fastCalls, 1)
+		w.Write([]byte(`{"errorcode":0,"group_ids":["fast"]}`))
+	}))
+	defer fast.Close()
+
+	y := Init(as, WithHost(strings.TrimPrefix(slow.URL, "http://")), WithHedging(20*time.Millisecond, strings.TrimPrefix(fast.URL, "http://")))
+	ggr, err := y.GetGroupIDs()
+	if err != nil || len(ggr.GroupIDs) != 1 || ggr.GroupIDs[0] != "fast" {
+		t.Errorf("GetGroupIDs: %+v, %v\n", ggr, err)
+	}
+	if atomic.LoadInt32(&slowCalls) != 1 || atomic.LoadInt32(&fastCalls) != 1 {
+		t.Errorf("calls: slow %d, fast %d\n", slowCalls, fastCalls)
+	}
+
+	//修改数据的接口不对冲
+	y = Init(as, WithHost(strings.TrimPrefix(fast.URL, "http://")), WithHedging(time.Nanosecond, strings.TrimPrefix(slow.URL, "http://")))
+	if _, err = y.DelPerson("alice"); err != nil {
+		t.Errorf("DelPerson failed: %s\n", err)
+	}
+	if atomic.LoadInt32(&slowCalls) != 1 {
+		t.Errorf("mutating call hedged\n")
+	}
+	//未注册的接口可能修改数据, 不对冲
+	var rsp map[string]interface{}
+	if err = y.Call("unknownwrite", map[string]string{"person_id": "alice"}, &rsp); err != nil {
+		t.Errorf("Call failed: %s\n", err)
+	}
+	if atomic.LoadInt32(&slowCalls) != 1 {
+		t.Errorf("unknown endpoint hedged\n")
+	}
+}
+
+func TestHedgingTimings(t *testing.T) {
+	unblock := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		select {
+		case <-unblock:
+		case <-r.Context().Done():
+		}
+		w.Write([]byte(`{"errorcode":0}`))
+	}))
+	defer slow.Close()
+	defer close(unblock)
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Write([]byte(`{"errorcode":0,"group_ids":["fast"]}`))
+	}))
+	defer fast.Close()
+
+	var tm Timings
+	traced := 0
+	ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+		GetConn: func(string) { traced++ },
+	})
+	ctx = ContextWithTimings(ctx, &tm)
+	y := Init(as, WithHost(strings.TrimPrefix(slow.URL, "http://")), WithHedging(10*time.Millisecond, strings.TrimPrefix(fast.URL, "http://")))
+	ggr, err := y.GetGroupIDsCtx(ctx)
+	if err != nil || len(ggr.GroupIDs) != 1 || ggr.GroupIDs[0] != "fast" {
+		t.Errorf("GetGroupIDsCtx: %+v, %v\n", ggr, err)
+	}
+	//两个请求并发进行, 由-race检查对trace和Timings的并发写入
+	if tm.TTFB <= 0 || tm.Connect <= 0 {
+		t.Errorf("timings of the hedged request: %+v\n", tm)
+	}
+	if traced != 0 {
+		t.Errorf("caller's ClientTrace called %d times during hedging\n", traced)
+	}
+}
+
+func TestHedgingScheduler(t *testing.T) {
+	var calls, inflight, maxInflight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInflight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInflight, m, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"errorcode":0}`))
+	}))
+	defer srv.Close()
+
+	//调度器只允许一个请求时不对冲
+	y := Init(as, WithHost(strings.TrimPrefix(srv.URL, "http://")), WithHedging(5*time.Millisecond, ""))
+	y.SetScheduler(NewScheduler(1, 0))
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs failed: %s\n", err)
+	}
+	if atomic.LoadInt32(&calls) != 1 || atomic.LoadInt32(&maxInflight) != 1 {
+		t.Errorf("limit 1: %d calls, %d concurrent, want 1\n", calls, maxInflight)
+	}
+
+	//有空闲名额时对冲请求占用名额
+	s := NewScheduler(2, 0)
+	y.SetScheduler(s)
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs failed: %s\n", err)
+	}
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("limit 2: %d calls, want 3\n", calls)
+	}
+	time.Sleep(100 * time.Millisecond)
+	s.mu.Lock()
+	if s.inflight != 0 {
+		t.Errorf("inflight after hedging: %d, want 0\n", s.inflight)
+	}
+	s.mu.Unlock()
+}
+
+func TestHedgingDecode(t *testing.T) {
+	//两个请求几乎同时返回较大的响应, 解码的时间有重叠
+	ids := func(prefix string) string {
+		s := make([]string, 20000)
+		for i := range s {
+			s[i] = fmt.Sprintf(`"%s%d"`, prefix, i)
+		}
+		return strings.Join(s, ",")
+	}
+	slowBody := `{"errorcode":0,"group_ids":[` + ids("slow") + `]}`
+	fastBody := `{"errorcode":-1,"errormsg":"fast","group_ids":[` + ids("fast") + `]}`
+	hedged := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		<-hedged
+		w.Write([]byte(slowBody))
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		close(hedged)
+		w.Write([]byte(fastBody))
+	}))
+	defer fast.Close()
+
+	//各自解码, 由-race检查对结果的并发写入, 结果不会混合
+	y := Init(as, WithHost(strings.TrimPrefix(slow.URL, "http://")), WithHedging(10*time.Millisecond, strings.TrimPrefix(fast.URL, "http://")))
+	ggr, err := y.GetGroupIDs()
+	if err != nil || len(ggr.GroupIDs) != 20000 {
+		t.Errorf("GetGroupIDs: %d group ids, %v\n", len(ggr.GroupIDs), err)
+		return
+	}
+	slowRsp := ggr.ErrorCode == 0 && ggr.ErrorMsg == "" && ggr.GroupIDs[0] == "slow0"
+	fastRsp := ggr.ErrorCode == -1 && ggr.ErrorMsg == "fast" && ggr.GroupIDs[0] == "fast0"
+	if !slowRsp && !fastRsp {
+		t.Errorf("GetGroupIDs: errorcode %d, errormsg %q, group_ids[0] %q, mixed responses\n", ggr.ErrorCode, ggr.ErrorMsg, ggr.GroupIDs[0])
+	}
+	time.Sleep(100 * time.Millisecond)
+}
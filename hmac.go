@@ -0,0 +1,32 @@
+/*
+* File Name:	hmac.go
+* Description:  通用的HMAC签名
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+//SignHMAC 以key计算message的HMAC-SHA256, 返回base64编码的签名.
+//与优图接口的签名无关, 优图平台没有回调通知, 用于应用在自己的服务之间(如转发识别结果的网关)校验消息
+func SignHMAC(key, message []byte) string {
+	return base64.StdEncoding.EncodeToString(hmacSum(key, message))
+}
+
+//VerifyHMAC 校验sig是否为SignHMAC(key, message)的结果, 以常数时间比较
+func VerifyHMAC(key, message []byte, sig string) bool {
+	mac, err := base64.StdEncoding.DecodeString(sig)
+	return err == nil && hmac.Equal(mac, hmacSum(key, message))
+}
+
+func hmacSum(key, message []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(message)
+	return h.Sum(nil)
+}
@@ -0,0 +1,27 @@
+/*
+* File Name:	hmac_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import "testing"
+
+func TestHMAC(t *testing.T) {
+	key, msg := []byte("key"), []byte("The quick brown fox jumps over the lazy dog")
+	//常用的HMAC-SHA256测试向量, 十六进制为f7bc83f4...2d1a3cd8
+	sig := SignHMAC(key, msg)
+	if sig != "97yD9DBThCSxMpjmqm+xQ+9NWaFJRhdZl0edvC0aPNg=" {
+		t.Errorf("SignHMAC: %s\n", sig)
+	}
+	if !VerifyHMAC(key, msg, sig) {
+		t.Errorf("VerifyHMAC rejected a valid signature\n")
+	}
+	for _, bad := range []string{"", "not base64!", SignHMAC(key, append(msg, '.')), SignHMAC([]byte("other"), msg)} {
+		if VerifyHMAC(key, msg, bad) {
+			t.Errorf("VerifyHMAC accepted %q\n", bad)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+* File Name:	imageapi.go
+* Description:  图像识别接口(imageapi)的返回
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+//FuzzyDetectRsp 模糊检测返回
+type FuzzyDetectRsp struct {
+	Fuzzy           bool    `json:"fuzzy"`            //图片是否模糊
+	FuzzyConfidence float32 `json:"fuzzy_confidence"` //模糊的置信度[0~1]
+	ErrorCode       int     `json:"errorcode"`        //返回状态码
+	ErrorMsg        string  `json:"errormsg"`         //返回错误消息
+}
+
+//FoodDetectRsp 美食检测返回
+type FoodDetectRsp struct {
+	Food           bool    `json:"food"`            //图片是否为美食
+	FoodConfidence float32 `json:"food_confidence"` //美食的置信度[0~1]
+	ErrorCode      int     `json:"errorcode"`       //返回状态码
+	ErrorMsg       string  `json:"errormsg"`        //返回错误消息
+}
+
+//Tag 图像识别的标签
+type Tag struct {
+	TagName       string `json:"tag_name"`       //标签名
+	TagConfidence int32  `json:"tag_confidence"` //置信度[0~100]
+}
+
+//ImageTagRsp 图像标签返回
+type ImageTagRsp struct {
+	Tags      []Tag  `json:"tags"`      //识别出的标签
+	ErrorCode int    `json:"errorcode"` //返回状态码
+	ErrorMsg  string `json:"errormsg"`  //返回错误消息
+}
+
+//tagConfidence 返回名为name的标签的置信度, 没有该标签时返回0
+func tagConfidence(tags []Tag, name string) int32 {
+	for _, t := range tags {
+		if t.TagName == name {
+			return t.TagConfidence
+		}
+	}
+	return 0
+}
+
+//ImagePornRsp 智能鉴黄返回. 标签包括normal, hot, porn等分类以及female-breast等细分部位
+type ImagePornRsp struct {
+	Tags      []Tag  `json:"tags"`      //识别出的标签
+	ErrorCode int    `json:"errorcode"` //返回状态码
+	ErrorMsg  string `json:"errormsg"`  //返回错误消息
+}
+
+//Normal 图片为正常的置信度[0~100]
+func (r ImagePornRsp) Normal() int32 {
+	return tagConfidence(r.Tags, "normal")
+}
+
+//Hot 图片为性感的置信度[0~100]
+func (r ImagePornRsp) Hot() int32 {
+	return tagConfidence(r.Tags, "hot")
+}
+
+//Porn 图片为色情的置信度[0~100]
+func (r ImagePornRsp) Porn() int32 {
+	return tagConfidence(r.Tags, "porn")
+}
+
+//ImageTerrorismRsp 暴恐识别返回. 标签为normal和各类暴恐元素
+type ImageTerrorismRsp struct {
+	Tags      []Tag  `json:"tags"`      //识别出的标签
+	ErrorCode int    `json:"errorcode"` //返回状态码
+	ErrorMsg  string `json:"errormsg"`  //返回错误消息
+}
+
+//Terrorism 图片含有暴恐元素的置信度[0~100], 即normal以外标签的最高置信度
+func (r ImageTerrorismRsp) Terrorism() (c int32) {
+	for _, t := range r.Tags {
+		if t.TagName != "normal" && t.TagConfidence > c {
+			c = t.TagConfidence
+		}
+	}
+	return
+}
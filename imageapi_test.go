@@ -0,0 +1,102 @@
+/*
+* File Name:	imageapi_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+//newImageAPIServer 返回一个stub, 对/youtu/path返回rsp, 其它路径或图片不是QUJD时返回404.
+//path为接口的family和名称, 如imageapi/fuzzydetect
+func newImageAPIServer(t *testing.T, path, rsp string) (*Youtu, func()) {
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/youtu/"+path || req["image"] != "QUJD" {
+			t.Errorf("unexpected request %s %v\n", r.URL.Path, req)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(rsp))
+	})
+	return y, srv.Close
+}
+
+func TestFuzzyDetect(t *testing.T) {
+	y, done := newImageAPIServer(t, "imageapi/fuzzydetect", `{"errorcode":0,"fuzzy":true,"fuzzy_confidence":0.87}`)
+	defer done()
+	fdr, err := y.FuzzyDetect("QUJD")
+	if err != nil || !fdr.Fuzzy || fdr.FuzzyConfidence != 0.87 {
+		t.Errorf("FuzzyDetect: %+v, %v\n", fdr, err)
+	}
+}
+
+func TestFoodDetect(t *testing.T) {
+	y, done := newImageAPIServer(t, "imageapi/fooddetect", `{"errorcode":0,"food":true,"food_confidence":0.95}`)
+	defer done()
+	fdr, err := y.FoodDetect("QUJD")
+	if err != nil || !fdr.Food || fdr.FoodConfidence != 0.95 {
+		t.Errorf("FoodDetect: %+v, %v\n", fdr, err)
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	y, done := newImageAPIServer(t, "imageapi/imagetag", `{"errorcode":0,"tags":[{"tag_name":"沙滩","tag_confidence":82},{"tag_name":"天空","tag_confidence":40}]}`)
+	defer done()
+	itr, err := y.ImageTag("QUJD")
+	if err != nil || len(itr.Tags) != 2 || itr.Tags[0] != (Tag{"沙滩", 82}) {
+		t.Errorf("ImageTag: %+v, %v\n", itr, err)
+	}
+}
+
+func TestImagePorn(t *testing.T) {
+	y, done := newImageAPIServer(t, "imageapi/imageporn", `{"errorcode":0,"tags":[{"tag_name":"normal","tag_confidence":10},{"tag_name":"hot","tag_confidence":25},{"tag_name":"porn","tag_confidence":65},{"tag_name":"female-breast","tag_confidence":40}]}`)
+	defer done()
+	ipr, err := y.ImagePorn("QUJD")
+	if err != nil || ipr.Normal() != 10 || ipr.Hot() != 25 || ipr.Porn() != 65 || len(ipr.Tags) != 4 {
+		t.Errorf("ImagePorn: %+v, %v\n", ipr, err)
+	}
+	if c := (ImagePornRsp{}).Porn(); c != 0 {
+		t.Errorf("Porn without tags: %d\n", c)
+	}
+}
+
+func TestImageTerrorism(t *testing.T) {
+	y, done := newImageAPIServer(t, "imageapi/imageterrorism", `{"errorcode":0,"tags":[{"tag_name":"normal","tag_confidence":90},{"tag_name":"knife","tag_confidence":30},{"tag_name":"blood","tag_confidence":12}]}`)
+	defer done()
+	itr, err := y.ImageTerrorism("QUJD")
+	if err != nil || itr.Terrorism() != 30 {
+		t.Errorf("ImageTerrorism: %+v, %v\n", itr, err)
+	}
+}
+
+func TestModerate(t *testing.T) {
+	terrorismCode := 0
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/youtu/imageapi/imageporn":
+			w.Write([]byte(`{"errorcode":0,"tags":[{"tag_name":"porn","tag_confidence":70}]}`))
+		case "/youtu/imageapi/imageterrorism":
+			fmt.Fprintf(w, `{"errorcode":%d,"tags":[{"tag_name":"guns","tag_confidence":55}]}`, terrorismCode)
+		}
+	})
+	defer srv.Close()
+	res, err := y.Moderate("QUJD")
+	if err != nil || res.Porn.Porn() != 70 || res.Terrorism.Terrorism() != 55 {
+		t.Errorf("Moderate: %+v, %v\n", res, err)
+	}
+	terrorismCode = -1
+	if _, err = y.Moderate("QUJD"); err == nil {
+		t.Errorf("Moderate with failing imageterrorism returned no error\n")
+	}
+}
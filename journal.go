@@ -0,0 +1,207 @@
+/*
+* File Name:	journal.go
+* Description:  批量修改的回滚日志
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+//InverseOp 一次修改的逆操作
+type InverseOp struct {
+	Interface  string   `json:"interface"`             //逆操作的接口名: delperson, delface或setinfo
+	PersonID   string   `json:"person_id"`             //个体ID
+	FaceIDs    []string `json:"face_ids,omitempty"`    //delface删除的人脸
+	PersonName string   `json:"person_name,omitempty"` //setinfo恢复的名字
+	Tag        string   `json:"tag,omitempty"`         //setinfo恢复的备注
+}
+
+//Journal 记录修改的逆操作, 用于回滚一次失败或错误的批量运行.
+//NewPerson, AddFace和SetInfo可以回滚; DelPerson和DelFace无法恢复, 不做记录.
+//可以被多个goroutine共用
+type Journal struct {
+	mu  sync.Mutex
+	ops []InverseOp
+	w   io.Writer
+	err error
+}
+
+//NewJournal 新建回滚日志, w不为nil时每条逆操作同时以JSONL格式写入w, 便于进程退出后回滚
+func NewJournal(w io.Writer) *Journal {
+	return &Journal{w: w}
+}
+
+//ReadJournal 读取由NewJournal写入的回滚日志
+func ReadJournal(r io.Reader) (*Journal, error) {
+	j := new(Journal)
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var op InverseOp
+		if err := json.Unmarshal(sc.Bytes(), &op); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		j.ops = append(j.ops, op)
+	}
+	return j, sc.Err()
+}
+
+//Ops 返回已记录的逆操作, 按记录的先后排列
+func (j *Journal) Ops() []InverseOp {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]InverseOp(nil), j.ops...)
+}
+
+//Err 返回写入w时的第一个错误
+func (j *Journal) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+//Flush 刷新w的缓冲(如*bufio.Writer)并同步到磁盘(如*os.File)
+func (j *Journal) Flush() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.w == nil {
+		return nil
+	}
+	return flushWriter(j.w)
+}
+
+func (j *Journal) append(op InverseOp) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.ops = append(j.ops, op)
+	if j.w == nil || j.err != nil {
+		return
+	}
+	line, err := json.Marshal(op)
+	if err == nil {
+		_, err = j.w.Write(append(line, '\n'))
+	}
+	j.err = err
+}
+
+//record 根据成功的修改记录逆操作, undo为prepareUndo预先准备的逆操作
+func (j *Journal) record(req, rsp interface{}, undo *InverseOp) {
+	switch rsp := rsp.(type) {
+	case *NewPersonRsp:
+		if r, ok := req.(newPersonReq); ok && rsp.ErrorCode == 0 {
+			j.append(InverseOp{Interface: "delperson", PersonID: r.PersonID})
+		}
+	case *AddFaceRsp:
+		if r, ok := req.(addFaceReq); ok && rsp.ErrorCode == 0 && len(rsp.FaceIDs) > 0 {
+			j.append(InverseOp{Interface: "delface", PersonID: r.PersonID, FaceIDs: rsp.FaceIDs})
+		}
+	case *SetInfoRsp:
+		if undo != nil && rsp.ErrorCode == 0 {
+			j.append(*undo)
+		}
+	}
+}
+
+//WithJournal 返回客户端副本, 通过它进行的修改(包括批量操作)都记录到j中
+func (y *Youtu) WithJournal(j *Journal) *Youtu {
+	c := *y
+	c.journal = j
+	return &c
+}
+
+//prepareUndo 对需要修改前状态才能回滚的请求(SetInfo)预先查询并准备逆操作
+func (y *Youtu) prepareUndo(ctx context.Context, req interface{}) (*InverseOp, error) {
+	r, ok := req.(setInfoReq)
+	if !ok {
+		return nil, nil
+	}
+	gir, err := y.GetInfoCtx(ctx, r.PersonID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkCode("getinfo", gir.ErrorCode, gir.ErrorMsg); err != nil {
+		return nil, err
+	}
+	return &InverseOp{Interface: "setinfo", PersonID: r.PersonID, PersonName: gir.PersonName, Tag: gir.Tag}, nil
+}
+
+//RollbackResult 回滚的结果
+type RollbackResult struct {
+	Done    []InverseOp  //成功执行的逆操作
+	Failed  []InverseOp  //执行失败的逆操作
+	Errors  []error      //与Failed一一对应的错误
+	Pending []InverseOp  //因取消或超出预算而未执行的逆操作, 可以稍后继续回滚
+	Summary BatchSummary //按执行顺序计的执行情况
+}
+
+//Rollback 按记录的相反顺序逐个执行j中的逆操作, 删除恰好是这次运行所添加的个体和人脸.
+//单个逆操作失败时继续执行其余的逆操作. 为保证顺序, opts.Concurrency不生效
+func (y *Youtu) Rollback(ctx context.Context, j *Journal, opts BatchOptions) (res RollbackResult) {
+	c := *y
+	c.journal = nil
+	ops := j.Ops()
+	n := len(ops)
+	errs := make([]error, n)
+	opts.Concurrency = 1
+	res.Summary = runBatch(ctx, n, opts, func(i int) {
+		errs[i] = c.undo(ctx, ops[n-1-i])
+	})
+	for _, i := range res.Summary.Completed {
+		op := ops[n-1-i]
+		if errs[i] != nil {
+			res.Failed = append(res.Failed, op)
+			res.Errors = append(res.Errors, errs[i])
+			continue
+		}
+		res.Done = append(res.Done, op)
+	}
+	for _, i := range res.Summary.Remaining {
+		res.Pending = append(res.Pending, ops[n-1-i])
+	}
+	return
+}
+
+//setInfoRestoreReq 回滚SetInfo的请求, 名字和备注为空时同样发送
+type setInfoRestoreReq struct {
+	AppID      string `json:"app_id"`
+	PersonID   string `json:"person_id"`
+	PersonName string `json:"person_name"`
+	Tag        string `json:"tag"`
+}
+
+func (y *Youtu) undo(ctx context.Context, op InverseOp) error {
+	switch op.Interface {
+	case "delperson":
+		dpr, err := y.DelPersonCtx(ctx, op.PersonID)
+		if err != nil {
+			return err
+		}
+		return checkCode(op.Interface, dpr.ErrorCode, dpr.ErrorMsg)
+	case "delface":
+		dfr, err := y.DelFaceCtx(ctx, op.PersonID, op.FaceIDs)
+		if err != nil {
+			return err
+		}
+		return checkCode(op.Interface, int(dfr.ErrorCode), dfr.ErrorMsg)
+	case "setinfo":
+		//SetInfo省略空的名字和备注, 回滚时需要显式发送空值才能清除
+		req := setInfoRestoreReq{AppID: y.appID(), PersonID: op.PersonID, PersonName: op.PersonName, Tag: op.Tag}
+		var sir SetInfoRsp
+		if err := y.interfaceRequest(ctx, "setinfo", req, &sir); err != nil {
+			return err
+		}
+		return checkCode(op.Interface, int(sir.ErrorCode), sir.ErrorMsg)
+	}
+	return fmt.Errorf("unknown inverse operation %s", op.Interface)
+}
@@ -0,0 +1,81 @@
+/*
+* File Name:	journal_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJournalRollback(t *testing.T) {
+	var calls []string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		ifname := r.URL.Path[len("/youtu/api/"):]
+		calls = append(calls, fmt.Sprintf("%s %v %v", ifname, req["person_id"], req["person_name"]))
+		switch ifname {
+		case "newperson":
+			fmt.Fprintf(w, `{"person_id":%q,"face_id":"f0","errorcode":0}`, req["person_id"])
+		case "addface":
+			fmt.Fprint(w, `{"added":2,"face_ids":["f1","f2"],"errorcode":0}`)
+		case "getinfo":
+			fmt.Fprint(w, `{"person_id":"old","person_name":"旧名字","tag":"t","errorcode":0}`)
+		default:
+			fmt.Fprint(w, `{"errorcode":0}`)
+		}
+	})
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	jy := y.WithJournal(NewJournal(&buf))
+	jy.NewPerson("image", "p1", []string{"g"}, "", "")
+	jy.AddFace([]string{"a", "b"}, "p1", "")
+	jy.SetInfo("old", "新名字", "")
+	jy.DelPerson("other")
+
+	j, err := ReadJournal(&buf)
+	if err != nil {
+		t.Errorf("ReadJournal failed: %s\n", err)
+		return
+	}
+	want := []InverseOp{
+		{Interface: "delperson", PersonID: "p1"},
+		{Interface: "delface", PersonID: "p1", FaceIDs: []string{"f1", "f2"}},
+		{Interface: "setinfo", PersonID: "old", PersonName: "旧名字", Tag: "t"},
+	}
+	if !reflect.DeepEqual(j.Ops(), want) {
+		t.Errorf("ops: %#v, want %#v\n", j.Ops(), want)
+	}
+
+	calls = nil
+	res := y.Rollback(context.Background(), j, BatchOptions{})
+	if len(res.Done) != 3 || len(res.Failed) != 0 {
+		t.Errorf("rollback: %#v\n", res)
+	}
+	wantCalls := []string{"setinfo old 旧名字", "delface p1 <nil>", "delperson p1 <nil>"}
+	if !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("rollback calls: %v, want %v\n", calls, wantCalls)
+	}
+
+	//修改前的名字为空时回滚同样发送person_name, 以清除SetInfo设置的名字
+	j, _ = ReadJournal(strings.NewReader(`{"interface":"setinfo","person_id":"p2"}` + "\n"))
+	calls = nil
+	if res = y.Rollback(context.Background(), j, BatchOptions{}); len(res.Done) != 1 {
+		t.Errorf("rollback: %#v\n", res)
+	}
+	if len(calls) != 1 || calls[0] != "setinfo p2 " {
+		t.Errorf("rollback calls: %q, want [\"setinfo p2 \"]\n", calls)
+	}
+}
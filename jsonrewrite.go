@@ -0,0 +1,45 @@
+/*
+* File Name:	jsonrewrite.go
+* Description:  改写JSON中的字符串
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+//rewriteJSONStrings 以f的返回值替换JSON中的每个字符串后重新编码, 用于在日志中省略图片等长字段.
+//key为字符串所在的字段名, 数组中的字符串为数组的字段名, 顶层为空. data不是JSON时返回错误
+func rewriteJSONStrings(data []byte, f func(key, s string) interface{}) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(rewriteStrings(v, "", f)); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+func rewriteStrings(v interface{}, key string, f func(key, s string) interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return f(key, v)
+	case []interface{}:
+		for i := range v {
+			v[i] = rewriteStrings(v[i], key, f)
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = rewriteStrings(v[k], k, f)
+		}
+	}
+	return v
+}
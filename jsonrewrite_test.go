@@ -0,0 +1,27 @@
+/*
+* File Name:	jsonrewrite_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRewriteJSONStrings(t *testing.T) {
+	data := []byte(`{"app_id":"10000","group_ids":["a","b"],"face":{"x":1,"tag":"<t>"}}`)
+	out, err := rewriteJSONStrings(data, func(key, s string) interface{} {
+		return key + "=" + s
+	})
+	want := `{"app_id":"app_id=10000","face":{"tag":"tag=<t>","x":1},"group_ids":["group_ids=a","group_ids=b"]}`
+	if err != nil || string(out) != want {
+		t.Errorf("rewriteJSONStrings: %s, %v, want %s\n", out, err, want)
+	}
+	if _, err = rewriteJSONStrings([]byte("<html>"), func(_, s string) interface{} { return strings.ToUpper(s) }); err == nil {
+		t.Errorf("rewriteJSONStrings of non-JSON did not fail\n")
+	}
+}
@@ -0,0 +1,15 @@
+/*
+* File Name:	liveapi.go
+* Description:  活体检测接口的返回
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+//LiveGetFourRsp 获取唇语验证码返回
+type LiveGetFourRsp struct {
+	ValidateData string `json:"validate_data"` //4位数字的唇语验证码
+	ErrorCode    int    `json:"errorcode"`     //返回状态码
+	ErrorMsg     string `json:"errormsg"`      //返回错误消息
+}
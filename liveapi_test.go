@@ -0,0 +1,29 @@
+/*
+* File Name:	liveapi_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestLiveGetFour(t *testing.T) {
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		if r.URL.Path != "/youtu/openliveapi/livegetfour" {
+			t.Errorf("unexpected path %s\n", r.URL.Path)
+		}
+		w.Write([]byte(`{"errorcode":0,"validate_data":"4927"}`))
+	})
+	defer srv.Close()
+	lgr, err := y.LiveGetFour()
+	if err != nil || lgr.ValidateData != "4927" {
+		t.Errorf("LiveGetFour: %+v, %v\n", lgr, err)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+* File Name:	logger.go
+* Description:  调用日志
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"time"
+)
+
+//LogEvent 一次接口调用的记录
+type LogEvent struct {
+	Interface  string        //接口名
+	RequestID  string        //请求ID
+	Duration   time.Duration //发送请求的耗时, 包括重试, 不包括排队
+	StatusCode int           //最后一次请求的HTTP状态码, 没有收到响应时为0
+	ErrorCode  int           //返回状态码
+	Retries    int           //重试次数
+	Err        error         //调用的错误
+}
+
+//Logger 接收每次接口调用的记录
+type Logger interface {
+	LogCall(ctx context.Context, e LogEvent)
+}
+
+//WithLogger 设置调用日志, 默认不记录
+func WithLogger(l Logger) Option {
+	return func(y *Youtu) {
+		y.logger = l
+	}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+//NewSlogLogger 返回以结构化日志输出调用记录的Logger, 成功的调用为Info级别, 失败的为Warn级别.
+//l为nil时使用slog.Default()
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogLogger{l: l}
+}
+
+func (s slogLogger) LogCall(ctx context.Context, e LogEvent) {
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.String("interface", e.Interface),
+		slog.String("request_id", e.RequestID),
+		slog.Duration("duration", e.Duration),
+		slog.Int("status", e.StatusCode),
+		slog.Int("errorcode", e.ErrorCode),
+		slog.Int("retries", e.Retries),
+	}
+	if e.Err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", e.Err.Error()))
+	}
+	s.l.LogAttrs(ctx, level, "youtu call", attrs...)
+}
+
+//callEvent 返回一次调用的记录, call为最后一次请求, 请求未发出时为nil.
+//响应直接解码时body为nil, 从call.decode中取errorcode
+func (y *Youtu) callEvent(ctx context.Context, ifname string, start time.Time, call *Call, body []byte, err error) LogEvent {
+	e := LogEvent{
+		Interface: ifname,
+		Duration:  y.now().Sub(start),
+		Err:       err,
+	}
+	e.RequestID, _ = RequestIDFromContext(ctx)
+	if call != nil {
+		e.StatusCode = call.StatusCode
+		e.Retries = call.Attempt - 1
+	}
+	if body == nil && call != nil && call.decode != nil {
+		if v := reflect.Indirect(reflect.ValueOf(call.decode)); v.Kind() == reflect.Struct {
+			if f := v.FieldByName("ErrorCode"); f.IsValid() && f.CanInt() {
+				e.ErrorCode = int(f.Int())
+			}
+		}
+		return e
+	}
+	var r struct {
+		ErrorCode int `json:"errorcode"`
+	}
+	if json.Unmarshal(body, &r) == nil {
+		e.ErrorCode = r.ErrorCode
+	}
+	return e
+}
@@ -0,0 +1,86 @@
+/*
+* File Name:	logger_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSlogLogger(t *testing.T) {
+	calls := 0
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"errorcode":-1101,"errormsg":"group not exist"}`))
+	})
+	defer srv.Close()
+	var buf bytes.Buffer
+	WithRetry(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond})(y)
+	WithLogger(NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil))))(y)
+	if _, err := y.GetPersonIDs("g"); err != nil {
+		t.Errorf("GetPersonIDs failed: %s\n", err)
+	}
+	var rec struct {
+		Level     string
+		Msg       string
+		Interface string
+		Status    int
+		ErrorCode int
+		Retries   int
+		Duration  int64
+	}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Errorf("log record %q: %s\n", buf.String(), err)
+		return
+	}
+	if rec.Level != "INFO" || rec.Msg != "youtu call" || rec.Interface != "getpersonids" || rec.Status != 200 || rec.ErrorCode != -1101 || rec.Retries != 1 || rec.Duration <= 0 {
+		t.Errorf("log record: %+v\n", rec)
+	}
+}
+
+type eventRecorder struct {
+	events []LogEvent
+}
+
+func (r *eventRecorder) LogCall(ctx context.Context, e LogEvent) {
+	r.events = append(r.events, e)
+}
+
+func TestLoggerBeforeSend(t *testing.T) {
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	defer srv.Close()
+	rec := new(eventRecorder)
+	WithLogger(rec)(y)
+	WithBreaker(NewBreaker(BreakerOptions{Failures: 1}))(y)
+	//熔断后请求没有发出, 同样记录
+	_, err1 := y.GetGroupIDs()
+	_, err2 := y.GetGroupIDs()
+	if err2 != ErrCircuitOpen {
+		t.Errorf("GetGroupIDs after a failure: %v, want ErrCircuitOpen\n", err2)
+	}
+	if len(rec.events) != 2 {
+		t.Errorf("got %d log events, want 2\n", len(rec.events))
+		return
+	}
+	if e := rec.events[0]; e.StatusCode != http.StatusBadGateway || e.Err != err1 {
+		t.Errorf("log event of the failed request: %+v\n", e)
+	}
+	if e := rec.events[1]; e.Interface != "getgroupids" || e.StatusCode != 0 || e.Err != ErrCircuitOpen {
+		t.Errorf("log event of the rejected request: %+v\n", e)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+* File Name:	manager.go
+* Description:  多租户客户端管理
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+//ManagerOptions 客户端管理器的配置, 由其创建的所有客户端共用
+type ManagerOptions struct {
+	Host        string            //服务地址, 为空时使用DefaultHost
+	Transport   http.RoundTripper //所有客户端共用的连接, 为nil时使用http.DefaultTransport
+	MaxInFlight int               //每个应用同时进行的请求数上限, 不大于0时不限制
+	QPS         float64           //每个应用每秒请求数上限, 不大于0时不限制
+	Burst       int               //每个应用允许连续发出的请求数, 见Scheduler.SetBurst
+	IdleTimeout time.Duration     //客户端闲置超过该时间后被移除, 为0时不移除
+	Configure   func(y *Youtu)    //新建客户端后调用, 用于设置预检查、审计日志等, 可以为nil
+}
+
+//managerKey 按应用、密钥、用户和签名有效期区分客户端
+type managerKey struct {
+	appID     uint32
+	secretID  string
+	secretKey string
+	userID    string
+	expired   uint32
+	ttl       time.Duration
+}
+
+type managedClient struct {
+	client   *Youtu
+	lastUsed time.Time
+}
+
+//ClientManager 为服务多个优图应用的平台按需创建和缓存客户端.
+//每个应用有独立的调度器, 按MaxInFlight和QPS限流, 所有客户端共用Transport
+type ClientManager struct {
+	opts      ManagerOptions
+	mu        sync.Mutex
+	clients   map[managerKey]*managedClient
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+//NewClientManager 新建客户端管理器
+func NewClientManager(opts ManagerOptions) *ClientManager {
+	if opts.Host == "" {
+		opts.Host = DefaultHost
+	}
+	return &ClientManager{
+		opts:    opts,
+		clients: make(map[managerKey]*managedClient),
+		now:     time.Now,
+	}
+}
+
+//Client 返回appSign对应的客户端, 不存在时新建.
+//appID, 密钥, userID和有效期都相同的签名共用同一个客户端
+func (m *ClientManager) Client(appSign AppSign) *Youtu {
+	key := managerKey{
+		appID:     appSign.appID,
+		secretID:  appSign.secretID,
+		secretKey: appSign.secretKey,
+		userID:    appSign.userID,
+		expired:   appSign.expired,
+		ttl:       appSign.ttl,
+	}
+	m.mu.Lock()
+	now := m.now()
+	var evicted []*Youtu
+	if m.opts.IdleTimeout > 0 && now.Sub(m.lastSweep) >= m.opts.IdleTimeout {
+		evicted = m.evictLocked(now)
+	}
+	mc, ok := m.clients[key]
+	if !ok {
+		y := Init(appSign, WithHost(m.opts.Host))
+		y.SetTransport(m.opts.Transport)
+		if m.opts.MaxInFlight > 0 || m.opts.QPS > 0 {
+			s := NewScheduler(m.opts.MaxInFlight, m.opts.QPS)
+			s.SetBurst(m.opts.Burst)
+			y.SetScheduler(s)
+		}
+		if m.opts.Configure != nil {
+			m.opts.Configure(y)
+		}
+		mc = &managedClient{client: y}
+		m.clients[key] = mc
+	}
+	mc.lastUsed = now
+	y := mc.client
+	m.mu.Unlock()
+	closeClients(evicted)
+	return y
+}
+
+//Evict 移除闲置超过IdleTimeout的客户端并关闭, 返回移除的个数.
+//Client会定期调用, 也可以由调用方定时调用. 调用方仍持有的被移除的客户端返回ErrClosed
+func (m *ClientManager) Evict() int {
+	m.mu.Lock()
+	evicted := m.evictLocked(m.now())
+	m.mu.Unlock()
+	closeClients(evicted)
+	return len(evicted)
+}
+
+//evictLocked 移除闲置的客户端, 返回的客户端需要在释放锁后关闭, Close会等待其进行中的请求
+func (m *ClientManager) evictLocked(now time.Time) (evicted []*Youtu) {
+	m.lastSweep = now
+	if m.opts.IdleTimeout <= 0 {
+		return
+	}
+	for key, mc := range m.clients {
+		if now.Sub(mc.lastUsed) > m.opts.IdleTimeout {
+			delete(m.clients, key)
+			evicted = append(evicted, mc.client)
+		}
+	}
+	return
+}
+
+//closeClients 关闭移除的客户端, 停止其后台任务并刷新日志
+func closeClients(clients []*Youtu) {
+	for _, y := range clients {
+		y.Close(context.Background())
+	}
+}
+
+//Len 返回缓存的客户端个数
+func (m *ClientManager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.clients)
+}
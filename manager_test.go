@@ -0,0 +1,90 @@
+/*
+* File Name:	manager_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+//countingTransport 记录经过的请求数
+type countingTransport struct {
+	n int
+}
+
+func (ct *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ct.n++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestClientManager(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"errorcode":0}`)
+	}))
+	defer srv.Close()
+	ct := &countingTransport{}
+	configured := 0
+	m := NewClientManager(ManagerOptions{
+		Host:        strings.TrimPrefix(srv.URL, "http://"),
+		Transport:   ct,
+		MaxInFlight: 2,
+		IdleTimeout: time.Minute,
+		Configure:   func(y *Youtu) { configured++ },
+	})
+	now := time.Unix(1500000000, 0)
+	m.now = func() time.Time { return now }
+
+	other := as
+	other.appID = 87654321
+	a := m.Client(as)
+	if m.Client(as) != a {
+		t.Errorf("client for the same app not cached\n")
+	}
+	b := m.Client(other)
+	if b == a || m.Len() != 2 || configured != 2 {
+		t.Errorf("clients: %d configured: %d, want 2 2\n", m.Len(), configured)
+	}
+	if a.scheduler == nil || a.scheduler == b.scheduler {
+		t.Errorf("each app should have its own scheduler\n")
+	}
+	if _, err := a.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs failed: %s\n", err)
+	}
+	if _, err := b.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs failed: %s\n", err)
+	}
+	if ct.n != 2 {
+		t.Errorf("shared transport saw %d requests, want 2\n", ct.n)
+	}
+
+	now = now.Add(50 * time.Second)
+	m.Client(as)
+	now = now.Add(30 * time.Second)
+	if n := m.Evict(); n != 1 || m.Len() != 1 {
+		t.Errorf("evicted %d, left %d, want 1 1\n", n, m.Len())
+	}
+	if m.Client(other) == b {
+		t.Errorf("evicted client returned\n")
+	}
+	if _, err := b.GetGroupIDs(); err != ErrClosed {
+		t.Errorf("evicted client: %v, want ErrClosed\n", err)
+	}
+
+	//userID或有效期不同的签名不共用客户端
+	user := as
+	user.userID = "other_user"
+	expiring := as
+	expiring.ttl = time.Hour
+	if c := m.Client(user); c == a || c == m.Client(expiring) || m.Client(expiring) == a {
+		t.Errorf("signatures with different userID or expiry share a client\n")
+	}
+}
@@ -0,0 +1,123 @@
+/*
+* File Name:	merge.go
+* Description:  合并个体
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"fmt"
+)
+
+//MergeOptions 合并个体的选项
+type MergeOptions struct {
+	Images     []string //源个体的人脸图片, 将加入到目标个体. 接口不支持移动face_id, 只能重新加入图片
+	PersonName string   //合并后目标个体的名字, 为空时不修改
+	Tag        string   //合并后目标个体的备注信息, 为空时不修改. 不作为加入的人脸的备注
+	DryRun     bool     //只检查并返回计划执行的步骤, 不做任何修改. 客户端开启了SetDryRun时同样如此
+	Force      bool     //没有人脸加入目标个体或源个体所在的组会丢失时, 仍然删除源个体
+}
+
+//MergeResult 合并个体的结果
+type MergeResult struct {
+	Steps        []string //执行(或DryRun时计划执行)的修改步骤
+	AddedFaceIDs []string //加入到目标个体的face_id
+	LostGroupIDs []string //源个体所在而目标个体不在的组, 接口不支持将个体加入已有的组, 需要调用方处理
+	Deleted      bool     //源个体是否已删除
+}
+
+//MergePersons 将个体srcID合并到dstID: 把源个体的人脸图片加入目标个体,
+//更新目标个体的名字和备注, 最后删除源个体.
+//除非opts.Force, 没有图片、AddFace没有返回face_id或LostGroupIDs不为空时不删除源个体并返回错误.
+//任一步骤失败时立即返回, 已完成的步骤记录在MergeResult中
+func (y *Youtu) MergePersons(srcID, dstID string, opts MergeOptions) (res MergeResult, err error) {
+	return y.MergePersonsCtx(context.Background(), srcID, dstID, opts)
+}
+
+//MergePersonsCtx 同MergePersons, ctx用于取消请求和设置截止时间
+func (y *Youtu) MergePersonsCtx(ctx context.Context, srcID, dstID string, opts MergeOptions) (res MergeResult, err error) {
+	if srcID == dstID {
+		err = fmt.Errorf("merge %s into itself", srcID)
+		return
+	}
+	src, err := y.GetInfoCtx(ctx, srcID)
+	if err != nil {
+		return
+	}
+	if err = checkCode("getinfo", src.ErrorCode, src.ErrorMsg); err != nil {
+		return
+	}
+	dst, err := y.GetInfoCtx(ctx, dstID)
+	if err != nil {
+		return
+	}
+	if err = checkCode("getinfo", dst.ErrorCode, dst.ErrorMsg); err != nil {
+		return
+	}
+	joined := make(map[string]bool, len(dst.GroupIDs))
+	for _, groupID := range dst.GroupIDs {
+		joined[groupID] = true
+	}
+	for _, groupID := range src.GroupIDs {
+		if !joined[groupID] {
+			res.LostGroupIDs = append(res.LostGroupIDs, groupID)
+		}
+	}
+
+	//客户端的dry-run模式下修改类接口返回零值, 按DryRun处理, 否则AddFace没有返回face_id会被当作失败
+	dryRun := opts.DryRun || y.dryRun != nil
+	if !opts.Force {
+		switch {
+		case len(opts.Images) == 0:
+			err = fmt.Errorf("merge %s: no images to add to %s, deleting the source would lose its faces", srcID, dstID)
+			return
+		case len(res.LostGroupIDs) > 0:
+			err = fmt.Errorf("merge %s: %s is not in groups %v, deleting the source would drop it from them", srcID, dstID, res.LostGroupIDs)
+			return
+		}
+	}
+
+	if len(opts.Images) > 0 {
+		res.Steps = append(res.Steps, fmt.Sprintf("addface person_id=%s images=%d", dstID, len(opts.Images)))
+		if !dryRun {
+			afr, err := y.AddFaceCtx(ctx, opts.Images, dstID, "")
+			if err != nil {
+				return res, err
+			}
+			if err = checkCode("addface", afr.ErrorCode, afr.ErrorMsg); err != nil {
+				return res, err
+			}
+			res.AddedFaceIDs = afr.FaceIDs
+			if len(res.AddedFaceIDs) == 0 && !opts.Force {
+				return res, fmt.Errorf("merge %s: addface added no faces to %s, source not deleted", srcID, dstID)
+			}
+		}
+	}
+	if opts.PersonName != "" || opts.Tag != "" {
+		res.Steps = append(res.Steps, fmt.Sprintf("setinfo person_id=%s person_name=%q tag=%q", dstID, opts.PersonName, opts.Tag))
+		if !dryRun {
+			sir, err := y.SetInfoCtx(ctx, dstID, opts.PersonName, opts.Tag)
+			if err != nil {
+				return res, err
+			}
+			if err = checkCode("setinfo", int(sir.ErrorCode), sir.ErrorMsg); err != nil {
+				return res, err
+			}
+		}
+	}
+	res.Steps = append(res.Steps, fmt.Sprintf("delperson person_id=%s", srcID))
+	if !dryRun {
+		dpr, err := y.DelPersonCtx(ctx, srcID)
+		if err != nil {
+			return res, err
+		}
+		if err = checkCode("delperson", dpr.ErrorCode, dpr.ErrorMsg); err != nil {
+			return res, err
+		}
+		res.Deleted = true
+	}
+	return
+}
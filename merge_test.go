@@ -0,0 +1,141 @@
+/*
+* File Name:	merge_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMergePersons(t *testing.T) {
+	var calls []string
+	var faceTag interface{}
+	dstGroups := `["g1"]`
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		ifname := r.URL.Path[len("/youtu/api/"):]
+		calls = append(calls, fmt.Sprintf("%s %s", ifname, req["person_id"]))
+		switch ifname {
+		case "getinfo":
+			if req["person_id"] == "src" {
+				fmt.Fprint(w, `{"person_id":"src","group_ids":["g1","g2"],"errorcode":0}`)
+				return
+			}
+			fmt.Fprintf(w, `{"person_id":"dst","group_ids":%s,"errorcode":0}`, dstGroups)
+		case "addface":
+			faceTag = req["tag"]
+			fmt.Fprint(w, `{"added":1,"face_ids":["f1"],"errorcode":0}`)
+		default:
+			fmt.Fprint(w, `{"errorcode":0}`)
+		}
+	})
+	defer srv.Close()
+
+	opts := MergeOptions{Images: []string{"image"}, PersonName: "name", Tag: "vip", DryRun: true, Force: true}
+	res, err := y.MergePersons("src", "dst", opts)
+	if err != nil {
+		t.Errorf("MergePersons dry run failed: %s\n", err)
+		return
+	}
+	if len(res.Steps) != 3 || res.Deleted || len(calls) != 2 {
+		t.Errorf("dry run: res %#v, calls %v\n", res, calls)
+	}
+	if !reflect.DeepEqual(res.LostGroupIDs, []string{"g2"}) {
+		t.Errorf("LostGroupIDs: %v\n", res.LostGroupIDs)
+	}
+
+	calls = nil
+	opts.DryRun, opts.Force = false, false
+	dstGroups = `["g1","g2"]`
+	if res, err = y.MergePersons("src", "dst", opts); err != nil {
+		t.Errorf("MergePersons failed: %s\n", err)
+		return
+	}
+	want := []string{"getinfo src", "getinfo dst", "addface dst", "setinfo dst", "delperson src"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls: %v, want %v\n", calls, want)
+	}
+	if !res.Deleted || !reflect.DeepEqual(res.AddedFaceIDs, []string{"f1"}) {
+		t.Errorf("res: %#v\n", res)
+	}
+	//Tag是个体的备注, 不作为人脸的备注
+	if faceTag != nil && faceTag != "" {
+		t.Errorf("addface tag: %v, want empty\n", faceTag)
+	}
+
+	//客户端的dry-run模式同opts.DryRun
+	calls = nil
+	recorded := 0
+	dry := y.DryRun(func(DryRunCall) { recorded++ })
+	if res, err = dry.MergePersons("src", "dst", opts); err != nil {
+		t.Errorf("MergePersons on a dry-run client failed: %s\n", err)
+		return
+	}
+	if len(res.Steps) != 3 || res.Deleted || len(calls) != 2 || recorded != 0 {
+		t.Errorf("dry-run client: res %#v, calls %v, recorded %d\n", res, calls, recorded)
+	}
+}
+
+func TestMergePersonsUnsafe(t *testing.T) {
+	var calls []string
+	var dstGroups string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		ifname := r.URL.Path[len("/youtu/api/"):]
+		calls = append(calls, ifname)
+		switch ifname {
+		case "getinfo":
+			if req["person_id"] == "src" {
+				fmt.Fprint(w, `{"person_id":"src","group_ids":["g1","g2"],"errorcode":0}`)
+				return
+			}
+			fmt.Fprintf(w, `{"person_id":"dst","group_ids":%s,"errorcode":0}`, dstGroups)
+		case "addface":
+			fmt.Fprint(w, `{"face_ids":[],"errorcode":0}`)
+		default:
+			fmt.Fprint(w, `{"errorcode":0}`)
+		}
+	})
+	defer srv.Close()
+
+	deleted := func() bool {
+		for _, c := range calls {
+			if c == "delperson" {
+				return true
+			}
+		}
+		return false
+	}
+	cases := []struct {
+		name      string
+		opts      MergeOptions
+		dstGroups string
+	}{
+		{"no images", MergeOptions{}, `["g1","g2"]`},
+		{"lost groups", MergeOptions{Images: []string{"image"}}, `["g1"]`},
+		{"no faces added", MergeOptions{Images: []string{"image"}}, `["g1","g2"]`},
+	}
+	for _, c := range cases {
+		calls, dstGroups = nil, c.dstGroups
+		res, err := y.MergePersons("src", "dst", c.opts)
+		if err == nil || res.Deleted || deleted() {
+			t.Errorf("%s: err %v, res %#v, calls %v\n", c.name, err, res, calls)
+		}
+	}
+
+	calls, dstGroups = nil, `["g1"]`
+	res, err := y.MergePersons("src", "dst", MergeOptions{Force: true})
+	if err != nil || !res.Deleted || !deleted() {
+		t.Errorf("forced merge: err %v, res %#v, calls %v\n", err, res, calls)
+	}
+}
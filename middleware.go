@@ -0,0 +1,50 @@
+/*
+* File Name:	middleware.go
+* Description:  请求拦截器
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+//Call 一次HTTP请求, 拦截器可以读取和修改
+type Call struct {
+	Interface      string      //接口名
+	RequestID      string      //请求ID, 同一调用的重试相同
+	Attempt        int         //第几次请求, 重试时大于1
+	Payload        []byte      //JSON编码的请求体
+	Header         http.Header //附加的请求头, 覆盖同名的默认请求头
+	StatusCode     int         //响应的HTTP状态码, 收到响应后设置
+	ResponseHeader http.Header //响应头, 收到响应后设置
+	decode         interface{} //不为nil时响应直接解码到decode
+}
+
+//RoundTripFunc 发送请求, 返回响应体
+type RoundTripFunc func(ctx context.Context, call *Call) (rsp []byte, err error)
+
+//Interceptor 拦截器, 包装next并在请求前后执行自定义逻辑, 如记录日志、修改请求头、统计耗时
+type Interceptor func(next RoundTripFunc) RoundTripFunc
+
+//WithInterceptors 添加拦截器, 先添加的在外层. 每次请求(包括重试)都经过拦截器
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(y *Youtu) {
+		y.interceptors = append(append([]Interceptor(nil), y.interceptors...), interceptors...)
+	}
+}
+
+//roundTrip 经过拦截器发送一次请求
+func (y *Youtu) roundTrip(ctx context.Context, url string, call *Call, timeout time.Duration) ([]byte, error) {
+	rt := func(ctx context.Context, call *Call) ([]byte, error) {
+		return y.get(ctx, url, call, timeout)
+	}
+	for i := len(y.interceptors) - 1; i >= 0; i-- {
+		rt = y.interceptors[i](rt)
+	}
+	return rt(ctx, call)
+}
@@ -0,0 +1,54 @@
+/*
+* File Name:	middleware_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestInterceptors(t *testing.T) {
+	var traceID string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		traceID = r.Header.Get("X-Trace-Id")
+		w.Write([]byte(`{"errorcode":0,"group_ids":["g"]}`))
+	})
+	defer srv.Close()
+	var order []string
+	var payload, rsp string
+	WithInterceptors(
+		func(next RoundTripFunc) RoundTripFunc {
+			return func(ctx context.Context, call *Call) ([]byte, error) {
+				order = append(order, "outer "+call.Interface)
+				call.Header.Set("X-Trace-Id", "abc")
+				body, err := next(ctx, call)
+				rsp = string(body)
+				return body, err
+			}
+		},
+		func(next RoundTripFunc) RoundTripFunc {
+			return func(ctx context.Context, call *Call) ([]byte, error) {
+				order = append(order, "inner")
+				payload = string(call.Payload)
+				return next(ctx, call)
+			}
+		},
+	)(y)
+	ggr, err := y.GetGroupIDs()
+	if err != nil || len(ggr.GroupIDs) != 1 {
+		t.Errorf("GetGroupIDs: %+v, %v\n", ggr, err)
+	}
+	if strings.Join(order, ",") != "outer getgroupids,inner" {
+		t.Errorf("interceptor order: %v\n", order)
+	}
+	if traceID != "abc" || !strings.Contains(payload, `"app_id"`) || !strings.Contains(rsp, "group_ids") {
+		t.Errorf("header %q, payload %q, response %q\n", traceID, payload, rsp)
+	}
+}
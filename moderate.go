@@ -0,0 +1,47 @@
+/*
+* File Name:	moderate.go
+* Description:  图片内容审核
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"sync"
+)
+
+//ModerationResult 图片内容审核的结果
+type ModerationResult struct {
+	Porn      ImagePornRsp      //智能鉴黄的结果
+	Terrorism ImageTerrorismRsp //暴恐识别的结果
+}
+
+//Moderate 同时调用ImagePorn和ImageTerrorism审核图片, 任一接口失败或errorcode非0时返回错误
+func (y *Youtu) Moderate(imageData string) (res ModerationResult, err error) {
+	return y.ModerateCtx(context.Background(), imageData)
+}
+
+//ModerateCtx 同Moderate, ctx用于取消请求和设置截止时间
+func (y *Youtu) ModerateCtx(ctx context.Context, imageData string) (res ModerationResult, err error) {
+	var (
+		wg      sync.WaitGroup
+		pornErr error
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if res.Porn, pornErr = y.ImagePornCtx(ctx, imageData); pornErr == nil {
+			pornErr = checkCode("imageporn", res.Porn.ErrorCode, res.Porn.ErrorMsg)
+		}
+	}()
+	if res.Terrorism, err = y.ImageTerrorismCtx(ctx, imageData); err == nil {
+		err = checkCode("imageterrorism", res.Terrorism.ErrorCode, res.Terrorism.ErrorMsg)
+	}
+	wg.Wait()
+	if err == nil {
+		err = pornErr
+	}
+	return
+}
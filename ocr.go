@@ -0,0 +1,95 @@
+/*
+* File Name:	ocr.go
+* Description:  OCR识别结果与敏感信息遮盖
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"regexp"
+	"strings"
+)
+
+//ItemCoord OCR字段在图片中的位置
+type ItemCoord struct {
+	X      int32 `json:"x"`      //左上角x
+	Y      int32 `json:"y"`      //左上角y
+	Width  int32 `json:"width"`  //宽度
+	Height int32 `json:"height"` //高度
+}
+
+//Rect 返回对应的image.Rectangle
+func (c ItemCoord) Rect() image.Rectangle {
+	return image.Rect(int(c.X), int(c.Y), int(c.X+c.Width), int(c.Y+c.Height))
+}
+
+//OcrItem OCR识别出的一个字段或文本行
+type OcrItem struct {
+	Item       string    `json:"item"`       //字段名, 如"卡号", 通用OCR中为空
+	ItemString string    `json:"itemstring"` //识别出的文本
+	ItemCoord  ItemCoord `json:"itemcoord"`  //文本在图片中的位置
+	ItemConf   float32   `json:"itemconf"`   //置信度[0~1]
+}
+
+//OcrOptions OCR接口的可选参数, 嵌入在OCR接口的请求中, 零值的参数不发送, 使用服务端的默认值.
+//只包含优图平台定义的参数: retimage见优图官方SDK(TencentYoutuyun)的namecardocr, 平台没有定义语言提示等参数
+type OcrOptions struct {
+	RetImage bool `json:"retimage,omitempty"` //是否返回处理后的图片
+}
+
+var (
+	idNumberPattern   = regexp.MustCompile(`\d{17}[\dXx]|\d{15}`)
+	cardNumberPattern = regexp.MustCompile(`\d{12,19}`)
+)
+
+//SensitiveOcrItem 默认的敏感字段判断: 文本中含有身份证号码(15或18位)或银行卡号(12~19位数字),
+//不论字段名. 判断前去掉文本中的空格
+func SensitiveOcrItem(item OcrItem) bool {
+	s := strings.Replace(item.ItemString, " ", "", -1)
+	return idNumberPattern.MatchString(s) || cardNumberPattern.MatchString(s)
+}
+
+//MaskRegions 用纯色块遮盖图片中的区域, 区域为相对于图片左上角的坐标
+type MaskRegions struct {
+	Rects   []image.Rectangle
+	Padding int         //各区域向外扩展的像素数, 弥补OCR坐标的误差
+	Color   color.Color //遮盖的颜色, 为nil时为黑色
+}
+
+//Preprocess 实现Preprocessor
+func (m MaskRegions) Preprocess(p *Picture) error {
+	b := p.Image.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), p.Image, b.Min, draw.Src)
+	c := m.Color
+	if c == nil {
+		c = color.Black
+	}
+	for _, r := range m.Rects {
+		r = r.Inset(-m.Padding).Intersect(dst.Bounds())
+		draw.Draw(dst, r, image.NewUniform(c), image.Point{}, draw.Src)
+	}
+	p.Image = dst
+	return nil
+}
+
+//RedactOcr 遮盖base64编码的证件图片中sensitive判断为敏感的OCR字段, 返回遮盖后的图片,
+//用于安全地保存审核用的副本. sensitive为nil时使用SensitiveOcrItem.
+//items的坐标应对应imageData本身, 上传前缩放过的图片需要先换算坐标
+func RedactOcr(imageData string, items []OcrItem, sensitive func(OcrItem) bool) (string, error) {
+	if sensitive == nil {
+		sensitive = SensitiveOcrItem
+	}
+	mask := MaskRegions{Padding: 2}
+	for _, item := range items {
+		if sensitive(item) {
+			mask.Rects = append(mask.Rects, item.ItemCoord.Rect())
+		}
+	}
+	return PreprocessChain{mask}.Apply(imageData)
+}
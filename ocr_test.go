@@ -0,0 +1,57 @@
+/*
+* File Name:	ocr_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestSensitiveOcrItem(t *testing.T) {
+	cases := []struct {
+		s    string
+		want bool
+	}{
+		{"11010519491231002X", true},
+		{"6222 0212 3456 7890 123", true},
+		{"张三", false},
+		{"2016.01.01-2036.01.01", false},
+	}
+	for _, c := range cases {
+		if got := SensitiveOcrItem(OcrItem{ItemString: c.s}); got != c.want {
+			t.Errorf("SensitiveOcrItem(%q) = %t, want %t\n", c.s, got, c.want)
+		}
+	}
+}
+
+func TestRedactOcr(t *testing.T) {
+	items := []OcrItem{
+		{Item: "姓名", ItemString: "张三", ItemCoord: ItemCoord{X: 10, Y: 10, Width: 40, Height: 10}},
+		{Item: "公民身份号码", ItemString: "11010519491231002X", ItemCoord: ItemCoord{X: 10, Y: 60, Width: 100, Height: 12}},
+	}
+	out, err := RedactOcr(encodeUniformPNG(200, 100, 200), items, nil)
+	if err != nil {
+		t.Errorf("RedactOcr failed: %s\n", err)
+		return
+	}
+	data, _ := base64.StdEncoding.DecodeString(out)
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("decode redacted image: %s\n", err)
+		return
+	}
+	if g := color.GrayModel.Convert(img.At(50, 65)).(color.Gray).Y; g != 0 {
+		t.Errorf("id number not masked: %d\n", g)
+	}
+	if g := color.GrayModel.Convert(img.At(20, 15)).(color.Gray).Y; g != 200 {
+		t.Errorf("name masked: %d\n", g)
+	}
+}
@@ -0,0 +1,295 @@
+/*
+* File Name:	ocrapi.go
+* Description:  OCR接口的参数和返回
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"image"
+	"strings"
+)
+
+//IDCardType 身份证的正反面
+type IDCardType int
+
+const (
+	//IDCardFront 正面, 即有照片的一面
+	IDCardFront IDCardType = iota
+	//IDCardBack 反面, 即有国徽的一面
+	IDCardBack
+)
+
+//IdcardOcrRsp 身份证OCR返回. 正面只返回正面的字段, 反面只返回签发机关和有效期
+type IdcardOcrRsp struct {
+	Name       string `json:"name"`       //姓名
+	Sex        string `json:"sex"`        //性别
+	Nation     string `json:"nation"`     //民族
+	Birth      string `json:"birth"`      //出生日期, 如"1990/1/1"
+	Address    string `json:"address"`    //住址
+	ID         string `json:"id"`         //身份证号
+	FrontImage string `json:"frontimage"` //base64编码的正面裁剪图片
+	Authority  string `json:"authority"`  //签发机关
+	ValidDate  string `json:"valid_date"` //有效期, 可用ParseValidity解析
+	BackImage  string `json:"backimage"`  //base64编码的反面裁剪图片
+	ErrorCode  int    `json:"errorcode"`  //返回状态码
+	ErrorMsg   string `json:"errormsg"`   //返回错误消息
+}
+
+//OcrItems 结构化OCR接口返回的字段
+type OcrItems []OcrItem
+
+//Field 返回字段名为item的第一个字段的文本, 没有该字段时返回空
+func (items OcrItems) Field(item string) string {
+	for _, it := range items {
+		if it.Item == item {
+			return it.ItemString
+		}
+	}
+	return ""
+}
+
+//Fields 返回字段名为item的所有字段的文本, 如名片上的多个电话
+func (items OcrItems) Fields(item string) (ss []string) {
+	for _, it := range items {
+		if it.Item == item {
+			ss = append(ss, it.ItemString)
+		}
+	}
+	return
+}
+
+//NamecardOcrRsp 名片OCR返回. 字段名为"姓名", "电话", "邮箱", "公司", "职位", "地址"等
+type NamecardOcrRsp struct {
+	Items     OcrItems `json:"items"`     //识别出的字段
+	ErrorCode int      `json:"errorcode"` //返回状态码
+	ErrorMsg  string   `json:"errormsg"`  //返回错误消息
+}
+
+//OcrPoint 文本框的一个顶点
+type OcrPoint struct {
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+}
+
+//OcrWord 文本行中的一个字
+type OcrWord struct {
+	Character  string  `json:"character"`  //字
+	Confidence float32 `json:"confidence"` //置信度[0~1]
+}
+
+//GeneralOcrItem 通用OCR识别出的一行文本
+type GeneralOcrItem struct {
+	OcrItem
+	Polygon []OcrPoint `json:"polygon"` //文本框的顶点, 按顺时针排列, 倾斜的文本行不是矩形
+	Words   []OcrWord  `json:"words"`   //逐字的识别结果
+}
+
+//Points 返回文本框的顶点. 服务端没有返回顶点时返回ItemCoord的四个角
+func (i GeneralOcrItem) Points() []image.Point {
+	if len(i.Polygon) == 0 {
+		r := i.ItemCoord.Rect()
+		return []image.Point{r.Min, {r.Max.X, r.Min.Y}, r.Max, {r.Min.X, r.Max.Y}}
+	}
+	ps := make([]image.Point, len(i.Polygon))
+	for n, p := range i.Polygon {
+		ps[n] = image.Pt(int(p.X), int(p.Y))
+	}
+	return ps
+}
+
+//GeneralOcrRsp 通用印刷体OCR返回
+type GeneralOcrRsp struct {
+	Items     []GeneralOcrItem `json:"items"`     //识别出的文本行, 按从上到下的顺序
+	ErrorCode int              `json:"errorcode"` //返回状态码
+	ErrorMsg  string           `json:"errormsg"`  //返回错误消息
+}
+
+//Text 返回以换行连接的全部文本
+func (r GeneralOcrRsp) Text() string {
+	lines := make([]string, len(r.Items))
+	for i, it := range r.Items {
+		lines[i] = it.ItemString
+	}
+	return strings.Join(lines, "\n")
+}
+
+//LicenseType 驾驶证OCR识别的证件类型
+type LicenseType int
+
+const (
+	//LicenseVehicle 行驶证
+	LicenseVehicle LicenseType = iota
+	//LicenseDriving 驾驶证
+	LicenseDriving
+)
+
+//DriverLicenseOcrRsp 行驶证/驾驶证OCR返回. 可用Vehicle或Driving取出对应证件的字段
+type DriverLicenseOcrRsp struct {
+	Items     OcrItems `json:"items"`     //识别出的字段
+	ErrorCode int      `json:"errorcode"` //返回状态码
+	ErrorMsg  string   `json:"errormsg"`  //返回错误消息
+}
+
+//VehicleLicense 行驶证的字段
+type VehicleLicense struct {
+	Plate        string //号牌号码, 可用ValidatePlate校验
+	VehicleType  string //车辆类型
+	Owner        string //所有人
+	Address      string //住址
+	UseCharacter string //使用性质
+	Model        string //品牌型号
+	VIN          string //车辆识别代号
+	EngineNumber string //发动机号码
+	RegisterDate string //注册日期
+	IssueDate    string //发证日期
+}
+
+//DrivingLicense 驾驶证的字段
+type DrivingLicense struct {
+	Number      string //证号
+	Name        string //姓名
+	Sex         string //性别
+	Nationality string //国籍
+	Address     string //住址
+	Birth       string //出生日期
+	IssueDate   string //初次领证日期
+	Class       string //准驾车型
+	ValidFrom   string //有效期起始日期
+	ValidTo     string //有效期截止日期
+}
+
+//Vehicle 按行驶证的字段名取出各字段, 用于type为LicenseVehicle的结果
+func (r DriverLicenseOcrRsp) Vehicle() VehicleLicense {
+	return VehicleLicense{
+		Plate:        r.Items.Field("号牌号码"),
+		VehicleType:  r.Items.Field("车辆类型"),
+		Owner:        r.Items.Field("所有人"),
+		Address:      r.Items.Field("住址"),
+		UseCharacter: r.Items.Field("使用性质"),
+		Model:        r.Items.Field("品牌型号"),
+		VIN:          r.Items.Field("车辆识别代号"),
+		EngineNumber: r.Items.Field("发动机号码"),
+		RegisterDate: r.Items.Field("注册日期"),
+		IssueDate:    r.Items.Field("发证日期"),
+	}
+}
+
+//Driving 按驾驶证的字段名取出各字段, 用于type为LicenseDriving的结果
+func (r DriverLicenseOcrRsp) Driving() DrivingLicense {
+	return DrivingLicense{
+		Number:      r.Items.Field("证号"),
+		Name:        r.Items.Field("姓名"),
+		Sex:         r.Items.Field("性别"),
+		Nationality: r.Items.Field("国籍"),
+		Address:     r.Items.Field("住址"),
+		Birth:       r.Items.Field("出生日期"),
+		IssueDate:   r.Items.Field("领证日期"),
+		Class:       r.Items.Field("准驾车型"),
+		ValidFrom:   r.Items.Field("起始日期"),
+		ValidTo:     r.Items.Field("有效日期"),
+	}
+}
+
+//BizLicenseOcrRsp 营业执照OCR返回. 可用License取出各字段
+type BizLicenseOcrRsp struct {
+	Items     OcrItems `json:"items"`     //识别出的字段
+	ErrorCode int      `json:"errorcode"` //返回状态码
+	ErrorMsg  string   `json:"errormsg"`  //返回错误消息
+}
+
+//BizLicense 营业执照的字段
+type BizLicense struct {
+	RegNumber      string //注册号, 新版执照为统一社会信用代码
+	Name           string //公司名称
+	Type           string //类型
+	Address        string //住所
+	Person         string //法定代表人
+	Capital        string //注册资本
+	EstablishDate  string //成立日期
+	BusinessPeriod string //营业期限
+	Scope          string //经营范围
+}
+
+//License 按营业执照的字段名取出各字段
+func (r BizLicenseOcrRsp) License() BizLicense {
+	return BizLicense{
+		RegNumber:      r.Items.Field("注册号"),
+		Name:           r.Items.Field("公司名称"),
+		Type:           r.Items.Field("类型"),
+		Address:        r.Items.Field("地址"),
+		Person:         r.Items.Field("法定代表人"),
+		Capital:        r.Items.Field("注册资本"),
+		EstablishDate:  r.Items.Field("成立日期"),
+		BusinessPeriod: r.Items.Field("营业期限"),
+		Scope:          r.Items.Field("经营范围"),
+	}
+}
+
+//PlateOcrItem 识别出的一个车牌, ItemString为车牌号
+type PlateOcrItem struct {
+	OcrItem
+	Color string `json:"color"` //车牌颜色, 如"蓝", "黄", "绿"
+}
+
+//PlateOcrRsp 车牌OCR返回
+type PlateOcrRsp struct {
+	Items     []PlateOcrItem `json:"items"`     //识别出的车牌, 图片中有多个车牌时有多项
+	ErrorCode int            `json:"errorcode"` //返回状态码
+	ErrorMsg  string         `json:"errormsg"`  //返回错误消息
+}
+
+//Best 返回置信度最高的车牌, 没有识别出车牌时ok为false
+func (r PlateOcrRsp) Best() (plate PlateOcrItem, ok bool) {
+	for _, p := range r.Items {
+		if !ok || p.ItemConf > plate.ItemConf {
+			plate, ok = p, true
+		}
+	}
+	return
+}
+
+//CreditCardOcrRsp 银行卡OCR返回. 可用Card取出各字段
+type CreditCardOcrRsp struct {
+	Items     OcrItems `json:"items"`     //识别出的字段
+	ErrorCode int      `json:"errorcode"` //返回状态码
+	ErrorMsg  string   `json:"errormsg"`  //返回错误消息
+}
+
+//BankCard 银行卡的字段
+type BankCard struct {
+	Number   string //卡号, 可用ValidateCardNumber校验
+	Bank     string //发卡行, 如"招商银行(03080000)"
+	Name     string //卡名字
+	Type     string //卡类型, 如"借记卡", "贷记卡"
+	Validity string //有效期, 如"08/21"
+}
+
+//Card 按银行卡的字段名取出各字段
+func (r CreditCardOcrRsp) Card() BankCard {
+	return BankCard{
+		Number:   r.Items.Field("卡号"),
+		Bank:     r.Items.Field("银行信息"),
+		Name:     r.Items.Field("卡名字"),
+		Type:     r.Items.Field("卡类型"),
+		Validity: r.Items.Field("有效期"),
+	}
+}
+
+//HandwritingOcrRsp 手写体OCR返回
+type HandwritingOcrRsp struct {
+	Items     []OcrItem `json:"items"`     //识别出的文本行, 按从上到下的顺序
+	ErrorCode int       `json:"errorcode"` //返回状态码
+	ErrorMsg  string    `json:"errormsg"`  //返回错误消息
+}
+
+//Text 返回以换行连接的全部文本
+func (r HandwritingOcrRsp) Text() string {
+	lines := make([]string, len(r.Items))
+	for i, it := range r.Items {
+		lines[i] = it.ItemString
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,162 @@
+/*
+* File Name:	ocrapi_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"encoding/json"
+	"image"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestIdcardOcr(t *testing.T) {
+	y, done := newImageAPIServer(t, "ocrapi/idcardocr", `{"errorcode":0,"name":"张三","sex":"男","nation":"汉","birth":"1990/1/1","address":"北京市海淀区","id":"11010819900101001X","frontimage":"RlJPTlQ="}`)
+	defer done()
+	ior, err := y.IdcardOcr("QUJD", IDCardFront, OcrOptions{})
+	if err != nil || ior.Name != "张三" || ior.ID != "11010819900101001X" || ior.FrontImage != "RlJPTlQ=" {
+		t.Errorf("IdcardOcr: %+v, %v\n", ior, err)
+	}
+}
+
+func TestOcrOptionsEncoded(t *testing.T) {
+	var req map[string]interface{}
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		req = nil
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &req)
+		w.Write([]byte(`{"errorcode":0,"authority":"北京市公安局","valid_date":"2016.01.01-2036.01.01"}`))
+	})
+	defer srv.Close()
+	ior, err := y.IdcardOcr("QUJD", IDCardBack, OcrOptions{RetImage: true})
+	if err != nil || ior.Authority != "北京市公安局" {
+		t.Errorf("IdcardOcr: %+v, %v\n", ior, err)
+	}
+	if req["card_type"] != 1.0 || req["retimage"] != true {
+		t.Errorf("IdcardOcr request %v, want card_type 1 and retimage\n", req)
+	}
+	if _, err = y.IdcardOcr("QUJD", IDCardFront, OcrOptions{}); err != nil {
+		t.Errorf("IdcardOcr failed: %s\n", err)
+	}
+	if _, ok := req["retimage"]; ok {
+		t.Errorf("IdcardOcr sent zero option: %v\n", req)
+	}
+
+	if _, err = y.GeneralOcr("QUJD", OcrOptions{RetImage: true}); err != nil {
+		t.Errorf("GeneralOcr failed: %s\n", err)
+	}
+	if req["image"] != "QUJD" || req["retimage"] != true {
+		t.Errorf("GeneralOcr request %v, want retimage\n", req)
+	}
+	if _, err = y.GeneralOcr("QUJD", OcrOptions{}); err != nil {
+		t.Errorf("GeneralOcr failed: %s\n", err)
+	}
+	if _, ok := req["retimage"]; ok || len(req) != 2 {
+		t.Errorf("GeneralOcr request %v, want only app_id and image\n", req)
+	}
+}
+
+func TestNamecardOcr(t *testing.T) {
+	y, done := newImageAPIServer(t, "ocrapi/namecardocr", `{"errorcode":0,"items":[{"item":"姓名","itemstring":"李四","itemcoord":{"x":20,"y":30,"width":80,"height":24},"itemconf":0.98},{"item":"电话","itemstring":"010-12345678"},{"item":"电话","itemstring":"13800000000"},{"item":"公司","itemstring":"某某科技有限公司"}]}`)
+	defer done()
+	nor, err := y.NamecardOcr("QUJD", OcrOptions{})
+	if err != nil || nor.Items.Field("姓名") != "李四" || nor.Items[0].ItemCoord.Width != 80 {
+		t.Errorf("NamecardOcr: %+v, %v\n", nor, err)
+	}
+	if phones := nor.Items.Fields("电话"); len(phones) != 2 || phones[1] != "13800000000" {
+		t.Errorf("Fields(电话) = %v\n", phones)
+	}
+	if s := nor.Items.Field("邮箱"); s != "" {
+		t.Errorf("Field(邮箱) = %q, want empty\n", s)
+	}
+}
+
+func TestGeneralOcr(t *testing.T) {
+	y, done := newImageAPIServer(t, "ocrapi/generalocr", `{"errorcode":0,"items":[{"itemstring":"第一行","itemconf":0.99,"itemcoord":{"x":10,"y":10,"width":100,"height":20},"words":[{"character":"第","confidence":0.99}]},{"itemstring":"第二行","itemconf":0.95,"polygon":[{"x":10,"y":40},{"x":110,"y":45},{"x":108,"y":65},{"x":8,"y":60}]}]}`)
+	defer done()
+	gor, err := y.GeneralOcr("QUJD", OcrOptions{})
+	if err != nil || len(gor.Items) != 2 || gor.Items[0].ItemConf != 0.99 || gor.Items[0].Words[0].Character != "第" {
+		t.Errorf("GeneralOcr: %+v, %v\n", gor, err)
+	}
+	if text := gor.Text(); text != "第一行\n第二行" {
+		t.Errorf("Text() = %q\n", text)
+	}
+	if ps := gor.Items[0].Points(); len(ps) != 4 || ps[2] != image.Pt(110, 30) {
+		t.Errorf("Points() from itemcoord = %v\n", ps)
+	}
+	if ps := gor.Items[1].Points(); len(ps) != 4 || ps[1] != image.Pt(110, 45) {
+		t.Errorf("Points() from polygon = %v\n", ps)
+	}
+}
+
+func TestDriverLicenseOcr(t *testing.T) {
+	y, done := newImageAPIServer(t, "ocrapi/driverlicenseocr", `{"errorcode":0,"items":[{"item":"号牌号码","itemstring":"京A12345"},{"item":"所有人","itemstring":"王五"},{"item":"品牌型号","itemstring":"大众牌FV7187"},{"item":"注册日期","itemstring":"2015-06-01"}]}`)
+	defer done()
+	dlr, err := y.DriverLicenseOcr("QUJD", LicenseVehicle, OcrOptions{})
+	if err != nil {
+		t.Errorf("DriverLicenseOcr: %s\n", err)
+	}
+	if v := dlr.Vehicle(); v.Plate != "京A12345" || v.Owner != "王五" || v.Model != "大众牌FV7187" || v.VIN != "" {
+		t.Errorf("Vehicle() = %+v\n", v)
+	}
+	d := DriverLicenseOcrRsp{Items: OcrItems{{Item: "证号", ItemString: "110108199001010018"}, {Item: "准驾车型", ItemString: "C1"}, {Item: "有效日期", ItemString: "2026-01-01"}}}.Driving()
+	if d.Number != "110108199001010018" || d.Class != "C1" || d.ValidTo != "2026-01-01" {
+		t.Errorf("Driving() = %+v\n", d)
+	}
+}
+
+func TestBizLicenseOcr(t *testing.T) {
+	y, done := newImageAPIServer(t, "ocrapi/bizlicenseocr", `{"errorcode":0,"items":[{"item":"注册号","itemstring":"91110108MA01234567"},{"item":"公司名称","itemstring":"某某科技有限公司"},{"item":"地址","itemstring":"北京市海淀区"},{"item":"法定代表人","itemstring":"赵六"}]}`)
+	defer done()
+	blr, err := y.BizLicenseOcr("QUJD", OcrOptions{})
+	if err != nil {
+		t.Errorf("BizLicenseOcr: %s\n", err)
+	}
+	if l := blr.License(); l.RegNumber != "91110108MA01234567" || l.Name != "某某科技有限公司" || l.Address != "北京市海淀区" || l.Person != "赵六" || l.Scope != "" {
+		t.Errorf("License() = %+v\n", l)
+	}
+}
+
+func TestPlateOcr(t *testing.T) {
+	y, done := newImageAPIServer(t, "ocrapi/plateocr", `{"errorcode":0,"items":[{"itemstring":"京A12345","itemconf":0.72,"color":"蓝"},{"itemstring":"沪B6789D","itemconf":0.93,"color":"绿"}]}`)
+	defer done()
+	por, err := y.PlateOcr("QUJD", OcrOptions{})
+	if err != nil || len(por.Items) != 2 {
+		t.Errorf("PlateOcr: %+v, %v\n", por, err)
+	}
+	if p, ok := por.Best(); !ok || p.ItemString != "沪B6789D" || p.Color != "绿" || p.ItemConf != 0.93 {
+		t.Errorf("Best() = %+v, %v\n", p, ok)
+	}
+	if _, ok := (PlateOcrRsp{}).Best(); ok {
+		t.Errorf("Best of no plates reported ok\n")
+	}
+}
+
+func TestCreditCardOcr(t *testing.T) {
+	y, done := newImageAPIServer(t, "ocrapi/creditcardocr", `{"errorcode":0,"items":[{"item":"卡号","itemstring":"6225 7600 0000 0001"},{"item":"卡类型","itemstring":"借记卡"},{"item":"银行信息","itemstring":"招商银行(03080000)"},{"item":"有效期","itemstring":"08/21"}]}`)
+	defer done()
+	cor, err := y.CreditCardOcr("QUJD", OcrOptions{})
+	if err != nil {
+		t.Errorf("CreditCardOcr: %s\n", err)
+	}
+	if c := cor.Card(); c.Number != "6225 7600 0000 0001" || c.Type != "借记卡" || c.Bank != "招商银行(03080000)" || c.Validity != "08/21" || c.Name != "" {
+		t.Errorf("Card() = %+v\n", c)
+	}
+}
+
+func TestHandwritingOcr(t *testing.T) {
+	y, done := newImageAPIServer(t, "ocrapi/handwritingocr", `{"errorcode":0,"items":[{"itemstring":"申请人","itemcoord":{"x":5,"y":8,"width":90,"height":30}},{"itemstring":"二〇二六年十月","itemcoord":{"x":5,"y":50,"width":160,"height":30}}]}`)
+	defer done()
+	hor, err := y.HandwritingOcr("QUJD", OcrOptions{})
+	if err != nil || len(hor.Items) != 2 || hor.Items[1].ItemCoord.Y != 50 {
+		t.Errorf("HandwritingOcr: %+v, %v\n", hor, err)
+	}
+	if text := hor.Text(); text != "申请人\n二〇二六年十月" {
+		t.Errorf("Text() = %q\n", text)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+* File Name:	ocrcrop.go
+* Description:  按OCR坐标裁剪图片
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"encoding/base64"
+	"image"
+	"strings"
+)
+
+//Scale 将坐标从uploaded尺寸的图片换算到original尺寸的图片,
+//用于上传前缩放过的图片. 任一尺寸为零值时原样返回
+func (c ItemCoord) Scale(uploaded, original image.Point) ItemCoord {
+	if uploaded.X == 0 || uploaded.Y == 0 || original.X == 0 || original.Y == 0 {
+		return c
+	}
+	sx := func(v int32) int32 { return int32(int64(v) * int64(original.X) / int64(uploaded.X)) }
+	sy := func(v int32) int32 { return int32(int64(v) * int64(original.Y) / int64(uploaded.Y)) }
+	return ItemCoord{X: sx(c.X), Y: sy(c.Y), Width: sx(c.Width), Height: sy(c.Height)}
+}
+
+//OcrCrop 一个OCR字段在原图中的区域和裁剪出的图片
+type OcrCrop struct {
+	Item  OcrItem
+	Rect  image.Rectangle //在原图中的区域, 可用于高亮显示
+	Image image.Image     //裁剪出的图片, 区域在原图之外时为nil
+}
+
+//CropOcrItems 按OCR字段的坐标从base64编码的原图中裁剪出每个字段, 结果与items一一对应.
+//uploaded为上传时的图片尺寸, 上传前缩放过时坐标按原图尺寸换算, 为零值时认为未缩放.
+//padding为各区域向外扩展的像素数
+func CropOcrItems(imageData string, items []OcrItem, uploaded image.Point, padding int) (crops []OcrCrop, err error) {
+	img, _, err := image.Decode(base64.NewDecoder(base64.StdEncoding, strings.NewReader(imageData)))
+	if err != nil {
+		return nil, ErrImageDecode
+	}
+	b := img.Bounds()
+	original := image.Pt(b.Dx(), b.Dy())
+	crops = make([]OcrCrop, len(items))
+	for i, item := range items {
+		r := item.ItemCoord.Scale(uploaded, original).Rect().Inset(-padding).Add(b.Min).Intersect(b)
+		crops[i] = OcrCrop{Item: item, Rect: r.Sub(b.Min)}
+		if r.Empty() {
+			continue
+		}
+		p := &Picture{Image: img}
+		if err = (Crop{Rect: r.Sub(b.Min)}).Preprocess(p); err != nil {
+			return nil, err
+		}
+		crops[i].Image = p.Image
+	}
+	return
+}
@@ -0,0 +1,42 @@
+/*
+* File Name:	ocrcrop_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"image"
+	"testing"
+)
+
+func TestCropOcrItems(t *testing.T) {
+	items := []OcrItem{
+		{ItemString: "line 1", ItemCoord: ItemCoord{X: 10, Y: 10, Width: 100, Height: 20}},
+		{ItemString: "outside", ItemCoord: ItemCoord{X: 900, Y: 900, Width: 10, Height: 10}},
+	}
+	//上传的是缩小一半的图片
+	crops, err := CropOcrItems(encodeUniformPNG(400, 200, 128), items, image.Pt(200, 100), 1)
+	if err != nil {
+		t.Errorf("CropOcrItems failed: %s\n", err)
+		return
+	}
+	if len(crops) != 2 {
+		t.Errorf("crops: %d, want 2\n", len(crops))
+		return
+	}
+	if crops[0].Rect != image.Rect(19, 19, 221, 61) {
+		t.Errorf("rect: %v\n", crops[0].Rect)
+	}
+	if b := crops[0].Image.Bounds(); b.Dx() != 202 || b.Dy() != 42 {
+		t.Errorf("crop bounds: %v\n", b)
+	}
+	if crops[1].Image != nil || !crops[1].Rect.Empty() {
+		t.Errorf("crop outside image: %+v\n", crops[1])
+	}
+	if c := (ItemCoord{X: 10, Y: 10, Width: 10, Height: 10}).Scale(image.Point{}, image.Pt(100, 100)); c.X != 10 {
+		t.Errorf("scale without uploaded size: %+v\n", c)
+	}
+}
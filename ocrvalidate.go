@@ -0,0 +1,136 @@
+/*
+* File Name:	ocrvalidate.go
+* Description:  OCR结果的校验
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	//ErrIDNumberFormat 身份证号码格式错误
+	ErrIDNumberFormat = errors.New("id number malformed")
+	//ErrIDNumberChecksum 身份证号码校验位错误
+	ErrIDNumberChecksum = errors.New("id number checksum mismatch")
+	//ErrIDNumberBirth 身份证号码中的出生日期无效
+	ErrIDNumberBirth = errors.New("id number birth date invalid")
+	//ErrCardNumber 银行卡号格式或Luhn校验错误
+	ErrCardNumber = errors.New("card number invalid")
+	//ErrPlateFormat 车牌号格式错误
+	ErrPlateFormat = errors.New("license plate malformed")
+	//ErrValidityFormat 有效期格式错误
+	ErrValidityFormat = errors.New("validity period malformed")
+)
+
+var (
+	idNumberFormat = regexp.MustCompile(`^\d{17}[\dX]$`)
+	plateFormat    = regexp.MustCompile(`^[京津沪渝冀豫云辽黑湘皖鲁新苏浙赣鄂桂甘晋蒙陕吉闽贵粤青藏川宁琼][A-HJ-NP-Z][A-HJ-NP-Z0-9]{4,5}[A-HJ-NP-Z0-9挂学警港澳]$`)
+)
+
+//idNumberWeights 身份证号码前17位的加权因子(GB 11643-1999)
+var idNumberWeights = [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+
+//ValidateIDNumber 校验18位身份证号码的格式、出生日期和校验位.
+//校验失败通常是识别错误, 应用可以提示用户重新拍摄
+func ValidateIDNumber(s string) error {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if !idNumberFormat.MatchString(s) {
+		return ErrIDNumberFormat
+	}
+	birth, err := time.Parse("20060102", s[6:14])
+	if err != nil || birth.Year() < 1900 || birth.After(time.Now()) {
+		return ErrIDNumberBirth
+	}
+	sum := 0
+	for i, w := range idNumberWeights {
+		sum += int(s[i]-'0') * w
+	}
+	if "10X98765432"[sum%11] != s[17] {
+		return ErrIDNumberChecksum
+	}
+	return nil
+}
+
+//ValidateCardNumber 校验银行卡号: 12~19位数字并满足Luhn校验. 忽略空格
+func ValidateCardNumber(s string) error {
+	s = strings.Replace(s, " ", "", -1)
+	if len(s) < 12 || len(s) > 19 {
+		return ErrCardNumber
+	}
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		c := s[len(s)-1-i]
+		if c < '0' || c > '9' {
+			return ErrCardNumber
+		}
+		d := int(c - '0')
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	if sum%10 != 0 {
+		return ErrCardNumber
+	}
+	return nil
+}
+
+//ValidatePlate 校验车牌号格式: 省份简称、发牌机关字母和5位(新能源为6位)字母数字,
+//字母不含I和O. 忽略空格和分隔点
+func ValidatePlate(s string) error {
+	s = strings.NewReplacer(" ", "", "·", "", ".", "").Replace(strings.ToUpper(s))
+	if !plateFormat.MatchString(s) {
+		return ErrPlateFormat
+	}
+	return nil
+}
+
+//ParseValidity 解析证件的有效期, 如"2016.01.01-2036.01.01", 日期也可以用-或/分隔.
+//结束为"长期"时end为零值
+func ParseValidity(s string) (start, end time.Time, err error) {
+	s = strings.Replace(strings.TrimSpace(s), " ", "", -1)
+	//日期本身可能用-分隔, 按长度切分
+	var from, to string
+	if i := strings.Index(s, "长期"); i > 0 && i == len(s)-len("长期") {
+		from, to = strings.TrimRight(s[:i], "-至"), ""
+	} else if len(s) == 21 {
+		from, to = s[:10], s[11:]
+	} else if strings.Contains(s, "至") {
+		parts := strings.SplitN(s, "至", 2)
+		from, to = parts[0], parts[1]
+	} else {
+		err = ErrValidityFormat
+		return
+	}
+	if start, err = parseOcrDate(from); err != nil {
+		return
+	}
+	if to == "" {
+		return
+	}
+	if end, err = parseOcrDate(to); err != nil {
+		return
+	}
+	if !end.After(start) {
+		err = ErrValidityFormat
+	}
+	return
+}
+
+func parseOcrDate(s string) (time.Time, error) {
+	s = strings.NewReplacer(".", "", "-", "", "/", "").Replace(s)
+	t, err := time.Parse("20060102", s)
+	if err != nil {
+		return t, ErrValidityFormat
+	}
+	return t, nil
+}
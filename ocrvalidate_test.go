@@ -0,0 +1,73 @@
+/*
+* File Name:	ocrvalidate_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateIDNumber(t *testing.T) {
+	cases := []struct {
+		s   string
+		err error
+	}{
+		{"11010519491231002X", nil},
+		{"11010519491231002x", nil},
+		{"110105194912310021", ErrIDNumberChecksum},
+		{"11010519491331002X", ErrIDNumberBirth},
+		{"1101051949123100", ErrIDNumberFormat},
+	}
+	for _, c := range cases {
+		if err := ValidateIDNumber(c.s); err != c.err {
+			t.Errorf("ValidateIDNumber(%q) = %v, want %v\n", c.s, err, c.err)
+		}
+	}
+}
+
+func TestValidateCardNumber(t *testing.T) {
+	if err := ValidateCardNumber("4111 1111 1111 1111"); err != nil {
+		t.Errorf("valid card: %v\n", err)
+	}
+	for _, s := range []string{"4111111111111112", "4111-1111-1111-1111", "12345"} {
+		if err := ValidateCardNumber(s); err != ErrCardNumber {
+			t.Errorf("ValidateCardNumber(%q) = %v\n", s, err)
+		}
+	}
+}
+
+func TestValidatePlate(t *testing.T) {
+	for _, s := range []string{"京A12345", "粤B·D12345", "沪a 1234学"} {
+		if err := ValidatePlate(s); err != nil {
+			t.Errorf("ValidatePlate(%q) = %v\n", s, err)
+		}
+	}
+	for _, s := range []string{"京I12345", "A12345", "京A1234"} {
+		if err := ValidatePlate(s); err != ErrPlateFormat {
+			t.Errorf("ValidatePlate(%q) = %v\n", s, err)
+		}
+	}
+}
+
+func TestParseValidity(t *testing.T) {
+	start, end, err := ParseValidity("2016.01.01-2036.01.01")
+	if err != nil || !start.Equal(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)) || end.Year() != 2036 {
+		t.Errorf("ParseValidity: %s %s %v\n", start, end, err)
+	}
+	if _, end, err = ParseValidity("2016-01-01-长期"); err != nil || !end.IsZero() {
+		t.Errorf("long term: %s %v\n", end, err)
+	}
+	if _, _, err = ParseValidity("2016.01.01至2036.01.01"); err != nil {
+		t.Errorf("with 至: %v\n", err)
+	}
+	for _, s := range []string{"2036.01.01-2016.01.01", "2016.13.01-2036.01.01", "forever"} {
+		if _, _, err = ParseValidity(s); err != ErrValidityFormat {
+			t.Errorf("ParseValidity(%q) = %v\n", s, err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+/*
+* File Name:	postprocess.go
+* Description:  返回结果的后处理
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"reflect"
+	"strings"
+)
+
+//PostProcessor 返回结果后处理的一个步骤, rsp为解码后的返回结构指针, 如*DetectFaceRsp.
+//不处理的返回类型应原样跳过
+type PostProcessor interface {
+	PostProcess(ifname string, rsp interface{}) error
+}
+
+//PostProcessFunc 以函数实现PostProcessor
+type PostProcessFunc func(ifname string, rsp interface{}) error
+
+//PostProcess 调用f(ifname, rsp)
+func (f PostProcessFunc) PostProcess(ifname string, rsp interface{}) error {
+	return f(ifname, rsp)
+}
+
+//PostProcessChain 依次执行的后处理步骤
+type PostProcessChain []PostProcessor
+
+//Apply 依次执行各步骤, 任一步骤出错时停止
+func (c PostProcessChain) Apply(ifname string, rsp interface{}) error {
+	for _, pp := range c {
+		if err := pp.PostProcess(ifname, rsp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//FaceFilter 从检测结果中去掉不满足条件的人脸. 值为0的条件不生效
+type FaceFilter struct {
+	MinWidth  float32 //人脸框的最小宽度
+	MinHeight float32 //人脸框的最小高度
+	MaxYaw    int32   //左右偏移绝对值的上限
+	MaxPitch  int32   //上下偏移绝对值的上限
+}
+
+//PostProcess 实现PostProcessor
+func (f FaceFilter) PostProcess(ifname string, rsp interface{}) error {
+	dfr, ok := rsp.(*DetectFaceRsp)
+	if !ok {
+		return nil
+	}
+	faces := dfr.Face[:0]
+	for _, face := range dfr.Face {
+		if f.keep(face) {
+			faces = append(faces, face)
+		}
+	}
+	dfr.Face = faces
+	return nil
+}
+
+func (f FaceFilter) keep(face Face) bool {
+	switch {
+	case f.MinWidth > 0 && face.Width < f.MinWidth:
+	case f.MinHeight > 0 && face.Height < f.MinHeight:
+	case f.MaxYaw > 0 && abs(face.Yaw) > f.MaxYaw:
+	case f.MaxPitch > 0 && abs(face.Pitch) > f.MaxPitch:
+	default:
+		return true
+	}
+	return false
+}
+
+//MinConfidence 置信度低于阈值的识别和验证结果视为不匹配:
+//识别结果清空person_id和face_id, 验证结果的ismatch置为false. 值为0的阈值不生效
+type MinConfidence struct {
+	Identify float32 //识别的置信度阈值
+	Verify   float32 //验证的置信度阈值
+}
+
+//PostProcess 实现PostProcessor
+func (m MinConfidence) PostProcess(ifname string, rsp interface{}) error {
+	switch r := rsp.(type) {
+	case *FaceIdentifyRsp:
+		if m.Identify > 0 && r.Confidence < m.Identify {
+			r.PersonID, r.FaceID = "", ""
+		}
+	case *FaceVerifyRsp:
+		if m.Verify > 0 && r.Confidence < m.Verify {
+			r.Ismatch = false
+		}
+	}
+	return nil
+}
+
+//ClampAttributes 将人脸属性限制在文档给出的范围内, 服务端偶尔返回越界的值
+type ClampAttributes struct{}
+
+//PostProcess 实现PostProcessor
+func (ClampAttributes) PostProcess(ifname string, rsp interface{}) error {
+	switch r := rsp.(type) {
+	case *DetectFaceRsp:
+		for i := range r.Face {
+			clampFace(&r.Face[i])
+		}
+	case *GetFaceInfoRsp:
+		clampFace(&r.FaceInfo)
+	}
+	return nil
+}
+
+func clampFace(f *Face) {
+	f.Gender = clamp(f.Gender, 0, 100)
+	f.Age = clamp(f.Age, 0, 100)
+	f.Expression = clamp(f.Expression, 0, 100)
+	f.Pitch = clamp(f.Pitch, -30, 30)
+	f.Yaw = clamp(f.Yaw, -30, 30)
+	f.Roll = clamp(f.Roll, -180, 180)
+}
+
+//Redact 将返回结构中JSON名为所列名字的字段置为零值, 包括嵌套的结构和切片,
+//如Redact{"person_name", "tag"}去掉个体的名字和备注
+type Redact []string
+
+//PostProcess 实现PostProcessor
+func (r Redact) PostProcess(ifname string, rsp interface{}) error {
+	names := make(map[string]bool, len(r))
+	for _, name := range r {
+		names[name] = true
+	}
+	redactValue(reflect.ValueOf(rsp), names)
+	return nil
+}
+
+func redactValue(v reflect.Value, names map[string]bool) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			redactValue(v.Elem(), names)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			redactValue(v.Index(i), names)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			if name == "" {
+				name = f.Name
+			}
+			if names[name] {
+				v.Field(i).Set(reflect.Zero(f.Type))
+				continue
+			}
+			redactValue(v.Field(i), names)
+		}
+	}
+}
+
+//SetPostProcess 设置返回结果的后处理步骤, 不带参数时关闭后处理
+func (y *Youtu) SetPostProcess(pp ...PostProcessor) {
+	y.postprocess = pp
+}
+
+//WithPostProcess 返回客户端副本, 其返回结果按pp后处理, 替换客户端的设置
+func (y *Youtu) WithPostProcess(pp ...PostProcessor) *Youtu {
+	c := *y
+	c.postprocess = pp
+	return &c
+}
@@ -0,0 +1,71 @@
+/*
+* File Name:	postprocess_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPostProcessSteps(t *testing.T) {
+	dfr := &DetectFaceRsp{SessionID: "s", Face: []Face{
+		{FaceID: "small", Width: 20, Height: 20},
+		{FaceID: "turned", Width: 100, Height: 100, Yaw: -25},
+		{FaceID: "ok", Width: 100, Height: 100, Age: 130, Roll: 200},
+	}}
+	chain := PostProcessChain{
+		FaceFilter{MinWidth: 48, MinHeight: 48, MaxYaw: 20},
+		ClampAttributes{},
+		Redact{"session_id"},
+	}
+	if err := chain.Apply("detectface", dfr); err != nil {
+		t.Errorf("Apply failed: %s\n", err)
+	}
+	if len(dfr.Face) != 1 || dfr.Face[0].FaceID != "ok" {
+		t.Errorf("filtered faces: %+v\n", dfr.Face)
+		return
+	}
+	if dfr.Face[0].Age != 100 || dfr.Face[0].Roll != 180 || dfr.SessionID != "" {
+		t.Errorf("clamped and redacted: %+v\n", dfr)
+	}
+
+	fir := &FaceIdentifyRsp{PersonID: "alice", FaceID: "f", Confidence: 55}
+	fvr := &FaceVerifyRsp{Ismatch: true, Confidence: 55}
+	m := MinConfidence{Identify: 60, Verify: 50}
+	m.PostProcess("faceidentify", fir)
+	m.PostProcess("faceverify", fvr)
+	if fir.PersonID != "" || fir.FaceID != "" || !fvr.Ismatch {
+		t.Errorf("thresholds: %+v %+v\n", fir, fvr)
+	}
+
+	gir := &GetInfoRsp{PersonID: "alice", PersonName: "Alice", Tag: "vip"}
+	Redact{"person_name", "tag"}.PostProcess("getinfo", gir)
+	if gir.PersonName != "" || gir.Tag != "" || gir.PersonID != "alice" {
+		t.Errorf("redacted: %+v\n", gir)
+	}
+}
+
+func TestSetPostProcess(t *testing.T) {
+	y, srv := newTestYoutu(`{"person_id":"alice","confidence":42,"errorcode":0}`)
+	defer srv.Close()
+	y.SetPostProcess(MinConfidence{Identify: 80})
+	fir, err := y.FaceIdentify("image", "g")
+	if err != nil || fir.PersonID != "" {
+		t.Errorf("FaceIdentify: %+v %v\n", fir, err)
+	}
+	errReject := errors.New("rejected")
+	reject := PostProcessFunc(func(ifname string, rsp interface{}) error {
+		return errReject
+	})
+	if _, err = y.WithPostProcess(reject).FaceIdentify("image", "g"); err != errReject {
+		t.Errorf("WithPostProcess: %v, want %v\n", err, errReject)
+	}
+	if fir, err = y.WithPostProcess().FaceIdentify("image", "g"); err != nil || fir.PersonID != "alice" {
+		t.Errorf("without post process: %+v %v\n", fir, err)
+	}
+}
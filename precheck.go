@@ -0,0 +1,130 @@
+/*
+* File Name:	precheck.go
+* Description:  上传前的本地图片检查
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"strings"
+)
+
+var (
+	//ErrImageDecode 图片无法解码错误
+	ErrImageDecode = errors.New("image can not be decoded")
+	//ErrImageTooSmall 图片分辨率过低错误
+	ErrImageTooSmall = errors.New("image resolution too low")
+	//ErrImageAspect 图片宽高比过于极端错误
+	ErrImageAspect = errors.New("image aspect ratio too extreme")
+	//ErrImageTooDark 图片接近全黑错误
+	ErrImageTooDark = errors.New("image too dark")
+	//ErrImageTooBright 图片接近全白错误
+	ErrImageTooBright = errors.New("image too bright")
+)
+
+//PreCheck 上传前的本地图片检查, 避免明显不可用的图片消耗调用配额.
+//值为0的检查项不生效
+type PreCheck struct {
+	MinWidth       int     //最小宽度
+	MinHeight      int     //最小高度
+	MaxAspectRatio float64 //长边与短边之比的上限
+	MinLuma        float64 //平均亮度[0~255]的下限, 低于时认为接近全黑
+	MaxLuma        float64 //平均亮度[0~255]的上限, 高于时认为接近全白
+}
+
+//DefaultPreCheck 默认的检查配置
+var DefaultPreCheck = PreCheck{
+	MinWidth:       48,
+	MinHeight:      48,
+	MaxAspectRatio: 4,
+	MinLuma:        10,
+	MaxLuma:        245,
+}
+
+//lumaSamples 计算平均亮度时每个方向的最大采样点数
+const lumaSamples = 64
+
+//Check 检查base64编码的图片, 不满足时返回对应的错误
+func (pc PreCheck) Check(imageData string) error {
+	dec := base64.NewDecoder(base64.StdEncoding, strings.NewReader(imageData))
+	img, _, err := image.Decode(dec)
+	if err != nil {
+		return ErrImageDecode
+	}
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w < pc.MinWidth || h < pc.MinHeight || w == 0 || h == 0 {
+		return ErrImageTooSmall
+	}
+	if pc.MaxAspectRatio > 0 {
+		long, short := w, h
+		if long < short {
+			long, short = short, long
+		}
+		if float64(long)/float64(short) > pc.MaxAspectRatio {
+			return ErrImageAspect
+		}
+	}
+	if pc.MinLuma == 0 && pc.MaxLuma == 0 {
+		return nil
+	}
+	luma := meanLuma(img)
+	if pc.MinLuma > 0 && luma < pc.MinLuma {
+		return ErrImageTooDark
+	}
+	if pc.MaxLuma > 0 && luma > pc.MaxLuma {
+		return ErrImageTooBright
+	}
+	return nil
+}
+
+//meanLuma 在网格上采样计算平均亮度
+func meanLuma(img image.Image) float64 {
+	b := img.Bounds()
+	xStep, yStep := b.Dx()/lumaSamples+1, b.Dy()/lumaSamples+1
+	var sum float64
+	var n int
+	for y := b.Min.Y; y < b.Max.Y; y += yStep {
+		for x := b.Min.X; x < b.Max.X; x += xStep {
+			sum += float64(color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y)
+			n++
+		}
+	}
+	return sum / float64(n)
+}
+
+//imager 携带图片数据的请求, 由endpoints.json中标记为image的字段生成
+type imager interface {
+	images() []string
+}
+
+//SetPreCheck 设置上传前的图片检查, 检查不通过的请求不会发出. pc为nil时关闭检查
+func (y *Youtu) SetPreCheck(pc *PreCheck) {
+	y.preCheck = pc
+}
+
+//preCheckRequest 检查请求中的所有图片
+func (y *Youtu) preCheckRequest(req interface{}) error {
+	if y.preCheck == nil {
+		return nil
+	}
+	r, ok := req.(imager)
+	if !ok {
+		return nil
+	}
+	for _, img := range r.images() {
+		if img == "" {
+			continue
+		}
+		if err := y.preCheck.Check(img); err != nil {
+			return err
+		}
+	}
+	return nil
+}
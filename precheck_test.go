@@ -0,0 +1,69 @@
+/*
+* File Name:	precheck_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"net/http"
+	"testing"
+)
+
+//encodeUniformPNG 生成指定尺寸和灰度的png图片并编码
+func encodeUniformPNG(width, height int, gray uint8) string {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = gray
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestPreCheck(t *testing.T) {
+	cases := []struct {
+		image string
+		err   error
+	}{
+		{encodeUniformPNG(320, 240, 128), nil},
+		{"bm90IGFuIGltYWdl", ErrImageDecode},
+		{encodeUniformPNG(32, 240, 128), ErrImageTooSmall},
+		{encodeUniformPNG(1000, 100, 128), ErrImageAspect},
+		{encodeUniformPNG(320, 240, 2), ErrImageTooDark},
+		{encodeUniformPNG(320, 240, 253), ErrImageTooBright},
+	}
+	for i, c := range cases {
+		if err := DefaultPreCheck.Check(c.image); err != c.err {
+			t.Errorf("case %d: Check: %v, want %v\n", i, err, c.err)
+		}
+	}
+	if err := (PreCheck{}).Check(encodeUniformPNG(1000, 1, 0)); err != nil {
+		t.Errorf("zero PreCheck: %v\n", err)
+	}
+}
+
+func TestSetPreCheck(t *testing.T) {
+	var calls int
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+	y.SetPreCheck(&DefaultPreCheck)
+	if _, err := y.DetectFace(encodeUniformPNG(320, 240, 0), DetectModeNormal); err != ErrImageTooDark {
+		t.Errorf("DetectFace: %v, want %v\n", err, ErrImageTooDark)
+	}
+	if _, err := y.NewPersonURL("http://example.com/a.jpg", "p", []string{"g"}, "", ""); err != nil {
+		t.Errorf("NewPersonURL failed: %s\n", err)
+	}
+	if calls != 1 {
+		t.Errorf("server calls: %d, want 1\n", calls)
+	}
+}
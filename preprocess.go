@@ -0,0 +1,329 @@
+/*
+* File Name:	preprocess.go
+* Description:  上传前的图片预处理
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+)
+
+//ErrCropEmpty 裁剪区域与图片没有交集错误
+var ErrCropEmpty = errors.New("crop rectangle outside image")
+
+//Picture 预处理中的图片
+type Picture struct {
+	Image       image.Image
+	Format      string //编码格式, 解码时为原图格式, 输出时jpeg以外的格式均编码为png
+	Orientation int    //EXIF方向[1~8], 没有EXIF时为1
+	Quality     int    //jpeg的编码质量[1~100], 为0时使用jpeg.DefaultQuality
+	MaxBytes    int    //jpeg编码后的大小上限, 超出时逐步降低质量, 为0时不限制
+}
+
+//Preprocessor 图片预处理的一个步骤
+type Preprocessor interface {
+	Preprocess(p *Picture) error
+}
+
+//PreprocessFunc 以函数实现Preprocessor
+type PreprocessFunc func(p *Picture) error
+
+//Preprocess 调用f(p)
+func (f PreprocessFunc) Preprocess(p *Picture) error {
+	return f(p)
+}
+
+//PreprocessChain 依次执行的预处理步骤
+type PreprocessChain []Preprocessor
+
+//Apply 解码base64编码的图片, 依次执行各步骤后重新编码. 链为空或图片为空时原样返回
+func (c PreprocessChain) Apply(imageData string) (string, error) {
+	if len(c) == 0 || imageData == "" {
+		return imageData, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(imageData)
+	if err != nil {
+		return "", ErrImageDecode
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", ErrImageDecode
+	}
+	p := &Picture{Image: img, Format: format, Orientation: 1}
+	if format == "jpeg" {
+		p.Orientation = exifOrientation(data)
+	}
+	for _, pp := range c {
+		if err = pp.Preprocess(p); err != nil {
+			return "", err
+		}
+	}
+	out, err := p.encode()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(out), nil
+}
+
+//encode 按Format编码, jpeg超出MaxBytes时每次降低10的质量重试, 最低为10
+func (p *Picture) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	if p.Format != "jpeg" {
+		err := png.Encode(&buf, p.Image)
+		return buf.Bytes(), err
+	}
+	quality := p.Quality
+	if quality <= 0 {
+		quality = jpeg.DefaultQuality
+	}
+	for {
+		buf.Reset()
+		if err := jpeg.Encode(&buf, p.Image, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, err
+		}
+		if p.MaxBytes <= 0 || buf.Len() <= p.MaxBytes || quality <= 10 {
+			return buf.Bytes(), nil
+		}
+		quality -= 10
+	}
+}
+
+//Resize 等比缩小图片, 使长边不超过MaxSide. 不放大
+type Resize struct {
+	MaxSide int
+}
+
+//Preprocess 实现Preprocessor
+func (r Resize) Preprocess(p *Picture) error {
+	b := p.Image.Bounds()
+	w, h := b.Dx(), b.Dy()
+	long := w
+	if h > long {
+		long = h
+	}
+	if r.MaxSide <= 0 || long <= r.MaxSide {
+		return nil
+	}
+	dw, dh := w*r.MaxSide/long, h*r.MaxSide/long
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := b.Min.Y+y*h/dh, b.Min.Y+(y+1)*h/dh
+		for x := 0; x < dw; x++ {
+			x0, x1 := b.Min.X+x*w/dw, b.Min.X+(x+1)*w/dw
+			dst.Set(x, y, boxAverage(p.Image, x0, y0, x1, y1))
+		}
+	}
+	p.Image = dst
+	return nil
+}
+
+//boxAverage 计算[x0,x1)×[y0,y1)内像素的平均颜色
+func boxAverage(img image.Image, x0, y0, x1, y1 int) color.Color {
+	var r, g, b, a, n uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			cr, cg, cb, ca := img.At(x, y).RGBA()
+			r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+			n++
+		}
+	}
+	if n == 0 {
+		return img.At(x0, y0)
+	}
+	return color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)}
+}
+
+//RotateEXIF 按EXIF方向旋转图片, 使像素方向与显示方向一致, 旋转后Orientation为1.
+//手机拍摄的照片常以EXIF记录方向, 而服务端按像素方向检测
+type RotateEXIF struct{}
+
+//Preprocess 实现Preprocessor
+func (RotateEXIF) Preprocess(p *Picture) error {
+	o := p.Orientation
+	if o < 2 || o > 8 {
+		return nil
+	}
+	b := p.Image.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if o >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch o {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, p.Image.At(b.Min.X+sx, b.Min.Y+sy))
+		}
+	}
+	p.Image = dst
+	p.Orientation = 1
+	return nil
+}
+
+//exifOrientation 读取jpeg中EXIF的方向, 没有或无法解析时返回1
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data) && data[i] == 0xFF; {
+		marker := data[i+1]
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		if marker == 0xDA || size < 2 || i+2+size > len(data) {
+			break
+		}
+		seg := data[i+4 : i+2+size]
+		if marker == 0xE1 && len(seg) > 6 && string(seg[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(seg[6:])
+		}
+		i += 2 + size
+	}
+	return 1
+}
+
+//tiffOrientation 在TIFF结构的IFD0中查找方向标签(0x0112)
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	n := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < n; i++ {
+		e := ifd + 2 + i*12
+		if e+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[e:]) == 0x0112 {
+			if o := int(order.Uint16(tiff[e+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			break
+		}
+	}
+	return 1
+}
+
+//Crop 裁剪图片, Rect为相对于图片左上角的区域, 超出图片的部分被忽略
+type Crop struct {
+	Rect image.Rectangle
+}
+
+//Preprocess 实现Preprocessor
+func (c Crop) Preprocess(p *Picture) error {
+	b := p.Image.Bounds()
+	r := c.Rect.Add(b.Min).Intersect(b)
+	if r.Empty() {
+		return ErrCropEmpty
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			dst.Set(x-r.Min.X, y-r.Min.Y, p.Image.At(x, y))
+		}
+	}
+	p.Image = dst
+	return nil
+}
+
+//Compress 以jpeg输出, 可指定质量和大小上限, 用于减少上传的数据量
+type Compress struct {
+	Quality  int //编码质量[1~100], 为0时使用jpeg.DefaultQuality
+	MaxBytes int //编码后的大小上限, 为0时不限制
+}
+
+//Preprocess 实现Preprocessor
+func (c Compress) Preprocess(p *Picture) error {
+	p.Format = "jpeg"
+	p.Quality = c.Quality
+	p.MaxBytes = c.MaxBytes
+	return nil
+}
+
+//Grayscale 转为灰度图
+type Grayscale struct{}
+
+//Preprocess 实现Preprocessor
+func (Grayscale) Preprocess(p *Picture) error {
+	b := p.Image.Bounds()
+	dst := image.NewGray(image.Rect(0, 0, b.Dx(), b.Dy()))
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			dst.Set(x-b.Min.X, y-b.Min.Y, p.Image.At(x, y))
+		}
+	}
+	p.Image = dst
+	return nil
+}
+
+//imageMapper 可以替换图片数据的请求, 由endpoints.json中标记为image的字段生成
+type imageMapper interface {
+	mapImages(fn func(string) (string, error)) (interface{}, error)
+}
+
+//SetPreprocess 设置请求中图片上传前的预处理步骤, 不带参数时关闭预处理
+func (y *Youtu) SetPreprocess(pp ...Preprocessor) {
+	y.preprocess = pp
+}
+
+//WithPreprocess 返回客户端副本, 其请求中的图片按pp预处理, 替换客户端的设置
+func (y *Youtu) WithPreprocess(pp ...Preprocessor) *Youtu {
+	c := *y
+	c.preprocess = pp
+	return &c
+}
+
+//preprocessRequest 返回图片经过预处理的请求
+func (y *Youtu) preprocessRequest(req interface{}) (interface{}, error) {
+	if len(y.preprocess) == 0 {
+		return req, nil
+	}
+	r, ok := req.(imageMapper)
+	if !ok {
+		return req, nil
+	}
+	return r.mapImages(y.preprocess.Apply)
+}
@@ -0,0 +1,119 @@
+/*
+* File Name:	preprocess_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+	"testing"
+)
+
+//withOrientation 在jpeg的SOI之后插入带方向标签的EXIF段
+func withOrientation(data []byte, o uint16) []byte {
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8, 0, 1, 0x01, 0x12, 0, 3, 0, 0, 0, 1, byte(o >> 8), byte(o), 0, 0, 0, 0, 0, 0}
+	seg := append([]byte("Exif\x00\x00"), tiff...)
+	size := len(seg) + 2
+	out := append([]byte{0xFF, 0xD8, 0xFF, 0xE1, byte(size >> 8), byte(size)}, seg...)
+	return append(out, data[2:]...)
+}
+
+func TestPreprocessSteps(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	src.Set(0, 0, color.RGBA{255, 0, 0, 255})
+	p := &Picture{Image: src, Orientation: 6}
+	RotateEXIF{}.Preprocess(p)
+	if b := p.Image.Bounds(); b.Dx() != 2 || b.Dy() != 4 || p.Orientation != 1 {
+		t.Errorf("rotated bounds: %v orientation: %d\n", b, p.Orientation)
+	}
+	//顺时针旋转90度后左上角移到右上角
+	if r, _, _, _ := p.Image.At(1, 0).RGBA(); r != 0xFFFF {
+		t.Errorf("rotated pixel not moved to top right\n")
+	}
+
+	p = &Picture{Image: image.NewRGBA(image.Rect(0, 0, 1000, 500))}
+	Resize{MaxSide: 100}.Preprocess(p)
+	if b := p.Image.Bounds(); b.Dx() != 100 || b.Dy() != 50 {
+		t.Errorf("resized bounds: %v\n", b)
+	}
+	Crop{Rect: image.Rect(10, 10, 40, 200)}.Preprocess(p)
+	if b := p.Image.Bounds(); b.Dx() != 30 || b.Dy() != 40 {
+		t.Errorf("cropped bounds: %v\n", b)
+	}
+	if err := (Crop{Rect: image.Rect(500, 500, 600, 600)}).Preprocess(p); err != ErrCropEmpty {
+		t.Errorf("crop outside: %v\n", err)
+	}
+	Grayscale{}.Preprocess(p)
+	if _, ok := p.Image.(*image.Gray); !ok {
+		t.Errorf("grayscale: %T\n", p.Image)
+	}
+}
+
+func TestExifOrientation(t *testing.T) {
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 8, 4)), nil)
+	if o := exifOrientation(buf.Bytes()); o != 1 {
+		t.Errorf("no exif: %d\n", o)
+	}
+	data := withOrientation(buf.Bytes(), 8)
+	if o := exifOrientation(data); o != 8 {
+		t.Errorf("orientation: %d, want 8\n", o)
+	}
+	out, err := PreprocessChain{RotateEXIF{}}.Apply(base64.StdEncoding.EncodeToString(data))
+	if err != nil {
+		t.Errorf("Apply failed: %s\n", err)
+		return
+	}
+	dec, _ := base64.StdEncoding.DecodeString(out)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(dec))
+	if err != nil || cfg.Width != 4 || cfg.Height != 8 {
+		t.Errorf("rotated image: %v %+v\n", err, cfg)
+	}
+}
+
+func TestWithPreprocess(t *testing.T) {
+	var sizes []image.Point
+	var formats []string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Images []string `json:"images"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, img := range req.Images {
+			data, _ := base64.StdEncoding.DecodeString(img)
+			cfg, format, _ := image.DecodeConfig(bytes.NewReader(data))
+			sizes = append(sizes, image.Pt(cfg.Width, cfg.Height))
+			formats = append(formats, format)
+		}
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+	images := []string{encodeUniformPNG(800, 600, 128), encodeUniformPNG(200, 100, 128)}
+	pp := y.WithPreprocess(Resize{MaxSide: 400}, Compress{Quality: 80})
+	if _, err := pp.AddFace(images, "alice", ""); err != nil {
+		t.Errorf("AddFace failed: %s\n", err)
+	}
+	if len(sizes) != 2 || sizes[0] != image.Pt(400, 300) || sizes[1] != image.Pt(200, 100) || formats[0] != "jpeg" {
+		t.Errorf("uploaded sizes: %v formats: %v\n", sizes, formats)
+	}
+	if images[0] != encodeUniformPNG(800, 600, 128) {
+		t.Errorf("caller's images modified\n")
+	}
+
+	sizes, formats = nil, nil
+	if _, err := y.AddFace(images[:1], "alice", ""); err != nil {
+		t.Errorf("AddFace failed: %s\n", err)
+	}
+	if len(sizes) != 1 || sizes[0] != image.Pt(800, 600) || formats[0] != "png" {
+		t.Errorf("client without preprocess changed the image\n")
+	}
+}
@@ -0,0 +1,94 @@
+/*
+* File Name:	registry.go
+* Description:  第三方接口注册
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+var (
+	//ErrEndpointExists 接口已存在错误
+	ErrEndpointExists = errors.New("endpoint already exists")
+	//ErrEndpointName 接口名为空错误
+	ErrEndpointName = errors.New("endpoint name is empty")
+)
+
+//Descriptor 第三方接口的描述, 私有化部署中常有自定义的接口
+type Descriptor struct {
+	Name     string //接口名, 如customdetect
+	Family   string //接口的路径族, 如api对应/youtu/api/<Name>, 为空时使用api
+	Upload   bool   //是否上传大量数据, 为true时使用较长的超时
+	Mutating bool   //是否修改服务端数据, 为true时受DryRun影响
+	Billable bool   //是否按调用计费, 用于Estimate
+}
+
+var (
+	registryMu sync.RWMutex
+	registered = make(map[string]endpoint)
+)
+
+//Register 注册一个接口, 注册后通过Call调用, 与内置接口一样进行签名.
+//接口名不能与内置或已注册的接口重复
+func Register(d Descriptor) error {
+	if d.Name == "" {
+		return ErrEndpointName
+	}
+	ep := endpoint{family: pathFamily(d.Family), timeout: timeoutNormal, mutating: d.Mutating, billable: d.Billable}
+	if ep.family == "" {
+		ep.family = familyAPI
+	}
+	if d.Upload {
+		ep.timeout = timeoutUpload
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := endpoints[d.Name]; ok {
+		return ErrEndpointExists
+	}
+	if _, ok := registered[d.Name]; ok {
+		return ErrEndpointExists
+	}
+	registered[d.Name] = ep
+	return nil
+}
+
+func lookupRegistered(ifname string) (ep endpoint, ok bool) {
+	registryMu.RLock()
+	ep, ok = registered[ifname]
+	registryMu.RUnlock()
+	return
+}
+
+//Call 调用接口ifname, req编码为JSON对象作为请求, 没有app_id字段时自动填充,
+//返回数据解码到rsp中. 一般用于通过Register注册的接口
+func (y *Youtu) Call(ifname string, req, rsp interface{}) (err error) {
+	return y.CallCtx(context.Background(), ifname, req, rsp)
+}
+
+//CallCtx 同Call, ctx用于取消请求和设置截止时间
+func (y *Youtu) CallCtx(ctx context.Context, ifname string, req, rsp interface{}) (err error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return
+	}
+	if fields == nil {
+		fields = make(map[string]json.RawMessage)
+	}
+	if _, ok := fields["app_id"]; !ok {
+		appID, _ := json.Marshal(y.appID())
+		fields["app_id"] = appID
+	}
+	return y.interfaceRequest(ctx, ifname, fields, rsp)
+}
@@ -0,0 +1,69 @@
+/*
+* File Name:	registry_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	if err := Register(Descriptor{Name: "detectface"}); err != ErrEndpointExists {
+		t.Errorf("Register builtin: %v, want %v\n", err, ErrEndpointExists)
+	}
+	if err := Register(Descriptor{}); err != ErrEndpointName {
+		t.Errorf("Register empty name: %v, want %v\n", err, ErrEndpointName)
+	}
+	if err := Register(Descriptor{Name: "customdetect", Family: "customapi", Upload: true}); err != nil {
+		t.Errorf("Register failed: %s\n", err)
+		return
+	}
+	if err := Register(Descriptor{Name: "customdetect"}); err != ErrEndpointExists {
+		t.Errorf("Register twice: %v, want %v\n", err, ErrEndpointExists)
+	}
+	if ep := lookupEndpoint("customdetect"); ep.family != "customapi" || ep.timeout != timeoutUpload {
+		t.Errorf("lookupEndpoint: %#v\n", ep)
+	}
+}
+
+func TestCall(t *testing.T) {
+	if err := Register(Descriptor{Name: "customcall"}); err != nil {
+		t.Errorf("Register failed: %s\n", err)
+		return
+	}
+	var path string
+	var body map[string]string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&body)
+		fmt.Fprint(w, `{"score":42,"errorcode":0,"errormsg":"OK"}`)
+	})
+	defer srv.Close()
+	req := struct {
+		Image string `json:"image"`
+	}{"image"}
+	var rsp struct {
+		Score     int `json:"score"`
+		ErrorCode int `json:"errorcode"`
+	}
+	if err := y.Call("customcall", req, &rsp); err != nil {
+		t.Errorf("Call failed: %s\n", err)
+		return
+	}
+	if path != "/youtu/api/customcall" {
+		t.Errorf("path: %s\n", path)
+	}
+	if body["app_id"] != y.appID() || body["image"] != "image" {
+		t.Errorf("body: %v\n", body)
+	}
+	if rsp.Score != 42 {
+		t.Errorf("rsp: %#v\n", rsp)
+	}
+}
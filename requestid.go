@@ -0,0 +1,54 @@
+/*
+* File Name:	requestid.go
+* Description:  请求ID
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+//RequestIDHeader 携带请求ID的请求头
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+//ContextWithRequestID 返回携带请求ID的ctx, 以该ctx调用接口时使用id作为请求ID,
+//便于与应用自身的请求日志关联
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+//RequestIDFromContext 返回ctx携带的请求ID
+func RequestIDFromContext(ctx context.Context) (id string, ok bool) {
+	id, ok = ctx.Value(requestIDKey{}).(string)
+	return
+}
+
+//withRequestID ctx没有携带请求ID时生成一个
+func withRequestID(ctx context.Context) context.Context {
+	if id, ok := RequestIDFromContext(ctx); ok && id != "" {
+		return ctx
+	}
+	return ContextWithRequestID(ctx, newRequestID())
+}
+
+//newRequestID 生成32位十六进制的随机请求ID
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+//requestIDSuffix 错误信息中请求ID的后缀
+func requestIDSuffix(id string) string {
+	if id == "" {
+		return ""
+	}
+	return " (request id " + id + ")"
+}
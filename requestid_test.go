@@ -0,0 +1,49 @@
+/*
+* File Name:	requestid_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestID(t *testing.T) {
+	var ids []string
+	fail := false
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(RequestIDHeader))
+		if fail {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	if _, err := y.GetGroupIDsCtx(ctx); err != nil || ids[0] != "req-1" {
+		t.Errorf("GetGroupIDsCtx: request id %q, %v\n", ids[0], err)
+	}
+
+	//没有请求ID时生成, 重试使用相同的请求ID
+	ids = nil
+	fail = true
+	WithRetry(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond})(y)
+	_, err := y.GetGroupIDs()
+	if len(ids) != 2 || len(ids[0]) != 32 || ids[0] != ids[1] {
+		t.Errorf("generated request ids: %q\n", ids)
+		return
+	}
+	se, ok := err.(*StatusError)
+	if !ok || se.RequestID != ids[0] || !strings.Contains(err.Error(), ids[0]) {
+		t.Errorf("error without request id: %v\n", err)
+	}
+}
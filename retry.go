@@ -0,0 +1,142 @@
+/*
+* File Name:	retry.go
+* Description:  失败请求的重试
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+//RetryPolicy 重试策略. 网络错误、HTTP 5xx和限流在重试次数内自动重试,
+//第n次重试前等待Backoff*2^(n-1), 不超过MaxBackoff, 限流时至少等待服务端要求的时间.
+//写接口(如AddFace)同样重试, 网络错误时请求可能已经生效, 需要应用容忍重复
+type RetryPolicy struct {
+	MaxAttempts int           //包括第一次在内的最多请求次数, 不大于1时不重试
+	Backoff     time.Duration //第一次重试前的等待时间
+	MaxBackoff  time.Duration //等待时间的上限, 不大于0时不限制
+	Jitter      float64       //等待时间随机减少的最大比例[0~1], 避免多个客户端同时重试
+}
+
+//DefaultRetryPolicy 默认的重试策略, 适合批量入库等后台任务
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     200 * time.Millisecond,
+	MaxBackoff:  5 * time.Second,
+	Jitter:      0.5,
+}
+
+//WithRetry 设置重试策略, 默认不重试
+func WithRetry(p RetryPolicy) Option {
+	return func(y *Youtu) {
+		y.retry = p
+	}
+}
+
+//retryable 判断错误是否可以重试, ctx已结束时不重试
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch e := err.(type) {
+	case *ThrottleError, net.Error:
+		return true
+	case *StatusError:
+		return e.Temporary()
+	}
+	return false
+}
+
+//overloaded 判断错误是否表示服务端过载: 限流或HTTP 5xx
+func overloaded(err error) bool {
+	switch e := err.(type) {
+	case *ThrottleError:
+		return true
+	case *StatusError:
+		return e.Temporary()
+	}
+	return false
+}
+
+//backoff 返回第attempt次重试前的等待时间, n返回[0, n)的随机数
+func (p RetryPolicy) backoff(attempt int, err error, n func(int64) int64) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if j := int64(float64(d) * p.Jitter); j > 0 {
+		d -= time.Duration(n(j + 1))
+	}
+	if te, ok := err.(*ThrottleError); ok && d < te.RetryAfter {
+		d = te.RetryAfter
+	}
+	return d
+}
+
+func (y *Youtu) int63n(n int64) int64 {
+	if y.random != nil {
+		return y.random.Int63n(n)
+	}
+	return rand.Int63n(n)
+}
+
+//decodeTarget 返回与decode同类型的零值, 每次请求解码到各自的值, 成功后再复制到decode,
+//失败的请求不会在decode中留下部分结果, 对冲的两个请求也不会同时写入decode
+func decodeTarget(decode interface{}) interface{} {
+	v := reflect.ValueOf(decode)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return decode
+	}
+	return reflect.New(v.Type().Elem()).Interface()
+}
+
+//send 发送请求, 按重试策略重试失败的请求, 返回最后一次请求及其结果. decode不为nil时响应直接解码到decode, 不返回响应体
+func (y *Youtu) send(ctx context.Context, ifname, url string, req []byte, decode interface{}, timeout time.Duration) (body []byte, call *Call, err error) {
+	requestID, _ := RequestIDFromContext(ctx)
+	for attempt := 1; ; attempt++ {
+		call = &Call{Interface: ifname, RequestID: requestID, Attempt: attempt, Payload: req, Header: make(http.Header), decode: decodeTarget(decode)}
+		if requestID != "" {
+			call.Header.Set(RequestIDHeader, requestID)
+		}
+		if y.hedging(ifname) {
+			body, call, err = y.hedge(ctx, url, call, timeout)
+		} else {
+			body, err = y.roundTrip(ctx, url, call, timeout)
+		}
+		if err == nil {
+			err = y.throttleCode(body)
+		}
+		switch e := err.(type) {
+		case *ThrottleError:
+			y.throttled(ifname, requestID, e)
+		case *StatusError:
+			e.Interface, e.RequestID = ifname, requestID
+		case *ResponseTooLargeError:
+			e.Interface, e.RequestID = ifname, requestID
+		}
+		if err == nil && call.decode != decode {
+			reflect.ValueOf(decode).Elem().Set(reflect.ValueOf(call.decode).Elem())
+		}
+		if err == nil || attempt >= y.retry.MaxAttempts || !retryable(ctx, err) {
+			return
+		}
+		t := time.NewTimer(y.retry.backoff(attempt, err, y.int63n))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+/*
+* File Name:	retry_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: time.Second, Jitter: 0.5}
+	max := func(n int64) int64 { return n - 1 }
+	cases := []struct {
+		attempt int
+		err     error
+		rand    func(int64) int64
+		want    time.Duration
+	}{
+		{1, nil, max, 50 * time.Millisecond},
+		{2, nil, max, 100 * time.Millisecond},
+		{3, nil, func(int64) int64 { return 0 }, 400 * time.Millisecond},
+		{10, nil, func(int64) int64 { return 0 }, time.Second},
+		{1, &ThrottleError{RetryAfter: 2 * time.Second}, max, 2 * time.Second},
+	}
+	for _, c := range cases {
+		if got := p.backoff(c.attempt, c.err, c.rand); got != c.want {
+			t.Errorf("backoff(%d, %v) = %s, want %s\n", c.attempt, c.err, got, c.want)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var calls, fails int32
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= atomic.LoadInt32(&fails) {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+
+	fails = 2
+	if _, err := y.GetGroupIDs(); err == nil {
+		t.Errorf("GetGroupIDs without retry succeeded, want server error\n")
+	}
+	WithRetry(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond})(y)
+	atomic.StoreInt32(&calls, 0)
+	if _, err := y.GetGroupIDs(); err != nil || calls != 3 {
+		t.Errorf("GetGroupIDs with retry: %v after %d calls, want success after 3\n", err, calls)
+	}
+	atomic.StoreInt32(&calls, 0)
+	fails = 5
+	if _, err := y.GetGroupIDs(); err == nil || calls != 3 {
+		t.Errorf("GetGroupIDs: %v after %d calls, want error after 3\n", err, calls)
+	}
+}
@@ -0,0 +1,299 @@
+/*
+* File Name:	scheduler.go
+* Description:  按优先级调度请求
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+//Priority 请求的优先级
+type Priority int
+
+const (
+	//PriorityInteractive 交互式请求, 如门禁处的人脸验证, 最先调度
+	PriorityInteractive Priority = iota
+	//PriorityNormal 普通请求, 默认的优先级
+	PriorityNormal
+	//PriorityBackground 后台请求, 如批量重新注册, 最后调度
+	PriorityBackground
+
+	numPriorities = iota
+)
+
+//Scheduler 按优先级调度共用同一限额的请求: 同时进行的请求数和每秒请求数受限时,
+//高优先级的请求先于等待中的低优先级请求发出. 可以被多个客户端共用.
+//每秒请求数按令牌桶限制, 桶的容量为burst, 空闲后允许连续发出burst个请求
+type Scheduler struct {
+	mu       sync.Mutex
+	max      int           //同时进行的请求数上限
+	interval time.Duration //生成一个令牌的时间, 为0时不限制
+	burst    int           //令牌桶的容量
+	adaptive bool          //是否自适应调整并发数上限
+	minLimit int           //自适应时并发数上限的下限
+	limit    float64       //自适应时当前的并发数上限, 不超过max
+	cut      time.Time     //自适应时上次减小并发数上限的时间
+	inflight int
+	full     time.Time //令牌桶装满的时间
+	paused   time.Time //服务端限流时暂停到该时间
+	timer    *time.Timer
+	queues   [numPriorities][]chan struct{}
+}
+
+//NewScheduler 新建调度器, maxInFlight为同时进行的请求数上限(不大于0时不限制),
+//qps为每秒请求数上限(不大于0时不限制)
+func NewScheduler(maxInFlight int, qps float64) *Scheduler {
+	s := &Scheduler{max: maxInFlight, burst: 1}
+	s.interval = qpsInterval(qps)
+	return s
+}
+
+//qpsInterval 返回每秒请求数qps对应的令牌生成间隔, qps不大于0时为0, 即不限制
+func qpsInterval(qps float64) time.Duration {
+	if qps <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / qps)
+}
+
+//setMaxInFlight 设置同时进行的请求数上限, 用于在已有的调度器上叠加WithAdaptiveConcurrency
+func (s *Scheduler) setMaxInFlight(max int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.max = max
+}
+
+//setQPS 设置每秒请求数, 用于在已有的调度器上叠加WithRateLimit
+func (s *Scheduler) setQPS(qps float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interval = qpsInterval(qps)
+}
+
+//SetBurst 设置每秒请求数受限时允许连续发出的请求数, 默认为1, 即请求间隔均匀
+func (s *Scheduler) SetBurst(burst int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if burst < 1 {
+		burst = 1
+	}
+	s.burst = burst
+}
+
+//SetAdaptive 按AIMD自适应调整并发数上限: 服务端限流或返回5xx时减半, 不低于min, 每秒最多减半一次;
+//请求成功时逐步增加, 每完成约一个上限数量的请求加1, 不超过NewScheduler的maxInFlight.
+//maxInFlight不大于0时无效
+func (s *Scheduler) SetAdaptive(min int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.max <= 0 {
+		return
+	}
+	if min < 1 {
+		min = 1
+	}
+	if min > s.max {
+		min = s.max
+	}
+	s.adaptive, s.minLimit, s.limit = true, min, float64(s.max)
+}
+
+//Limit 返回当前的并发数上限, 不限制时为0
+func (s *Scheduler) Limit() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.limitLocked()
+}
+
+func (s *Scheduler) limitLocked() int {
+	if s.adaptive {
+		return int(s.limit)
+	}
+	return s.max
+}
+
+//feedback 根据请求的结果调整自适应的并发数上限, overloaded表示服务端过载(限流或5xx)
+func (s *Scheduler) feedback(overloaded bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.adaptive {
+		return
+	}
+	if !overloaded {
+		if s.limit += 1 / s.limit; s.limit > float64(s.max) {
+			s.limit = float64(s.max)
+		}
+		s.dispatchLocked()
+		return
+	}
+	now := time.Now()
+	if now.Sub(s.cut) < time.Second {
+		return
+	}
+	s.cut = now
+	if s.limit /= 2; s.limit < float64(s.minLimit) {
+		s.limit = float64(s.minLimit)
+	}
+}
+
+//acquire 等待调度, 返回请求完成后需要调用的release
+func (s *Scheduler) acquire(ctx context.Context, p Priority) (release func(), err error) {
+	if p < 0 || p >= numPriorities {
+		p = PriorityNormal
+	}
+	ch := make(chan struct{})
+	s.mu.Lock()
+	s.queues[p] = append(s.queues[p], ch)
+	s.dispatchLocked()
+	s.mu.Unlock()
+	select {
+	case <-ch:
+		return s.release, nil
+	case <-ctx.Done():
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, c := range s.queues[p] {
+		if c == ch {
+			s.queues[p] = append(s.queues[p][:i], s.queues[p][i+1:]...)
+			return nil, ctx.Err()
+		}
+	}
+	//已被调度, 归还名额
+	s.inflight--
+	s.dispatchLocked()
+	return nil, ctx.Err()
+}
+
+//tryAcquire 不等待地获取名额和令牌, 有等待中的请求或名额、令牌不足时返回false, 用于可以放弃的请求, 如对冲请求
+func (s *Scheduler) tryAcquire() (release func(), ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for p := range s.queues {
+		if len(s.queues[p]) > 0 {
+			return nil, false
+		}
+	}
+	if s.max > 0 && s.inflight >= s.limitLocked() {
+		return nil, false
+	}
+	now := time.Now()
+	if now.Before(s.nextLocked()) {
+		return nil, false
+	}
+	s.takeLocked(now)
+	return s.release, true
+}
+
+//takeLocked 占用一个名额, 每秒请求数受限时从令牌桶中取出一个令牌
+func (s *Scheduler) takeLocked(now time.Time) {
+	if s.interval > 0 {
+		if s.full.Before(now) {
+			s.full = now
+		}
+		s.full = s.full.Add(s.interval)
+	}
+	s.inflight++
+}
+
+func (s *Scheduler) release() {
+	s.mu.Lock()
+	s.inflight--
+	s.dispatchLocked()
+	s.mu.Unlock()
+}
+
+func (s *Scheduler) dispatch() {
+	s.mu.Lock()
+	s.timer = nil
+	s.dispatchLocked()
+	s.mu.Unlock()
+}
+
+//dispatchLocked 在名额和间隔允许时按优先级唤醒等待的请求
+func (s *Scheduler) dispatchLocked() {
+	for p := range s.queues {
+		for len(s.queues[p]) > 0 {
+			if s.max > 0 && s.inflight >= s.limitLocked() {
+				return
+			}
+			now := time.Now()
+			if next := s.nextLocked(); now.Before(next) {
+				if s.timer == nil {
+					s.timer = time.AfterFunc(next.Sub(now), s.dispatch)
+				}
+				return
+			}
+			s.takeLocked(now)
+			ch := s.queues[p][0]
+			s.queues[p] = s.queues[p][1:]
+			close(ch)
+		}
+	}
+}
+
+//nextLocked 返回下一个请求最早的发出时间, 即令牌桶中有令牌且不在暂停中的时间
+func (s *Scheduler) nextLocked() time.Time {
+	next := s.paused
+	if s.interval > 0 {
+		if t := s.full.Add(-time.Duration(s.burst-1) * s.interval); t.After(next) {
+			next = t
+		}
+	}
+	return next
+}
+
+//pause 在d之内不再发出新的请求, 用于服务端要求限流时
+func (s *Scheduler) pause(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if until := time.Now().Add(d); until.After(s.paused) {
+		s.paused = until
+	}
+}
+
+//WithAdaptiveConcurrency 限制同时进行的请求数, 上限在min和max之间按服务端的限流自适应调整, 见Scheduler.SetAdaptive.
+//与WithRateLimit设置同一个调度器, 可以同时使用
+func WithAdaptiveConcurrency(min, max int) Option {
+	return func(y *Youtu) {
+		s := y.ensureScheduler()
+		s.setMaxInFlight(max)
+		s.SetAdaptive(min)
+	}
+}
+
+//WithRateLimit 按令牌桶限制客户端的每秒请求数, 多个goroutine共用客户端时自动排队,
+//burst为空闲后允许连续发出的请求数. 与WithAdaptiveConcurrency设置同一个调度器, 可以同时使用
+func WithRateLimit(qps float64, burst int) Option {
+	return func(y *Youtu) {
+		s := y.ensureScheduler()
+		s.setQPS(qps)
+		s.SetBurst(burst)
+	}
+}
+
+//ensureScheduler 返回客户端的调度器, 没有时新建一个不限制的调度器
+func (y *Youtu) ensureScheduler() *Scheduler {
+	if y.scheduler == nil {
+		y.scheduler = NewScheduler(0, 0)
+	}
+	return y.scheduler
+}
+
+//SetScheduler 设置请求的调度器, s为nil时不调度
+func (y *Youtu) SetScheduler(s *Scheduler) {
+	y.scheduler = s
+}
+
+//WithPriority 返回客户端副本, 其请求以优先级p调度
+func (y *Youtu) WithPriority(p Priority) *Youtu {
+	c := *y
+	c.priority = p
+	return &c
+}
@@ -0,0 +1,156 @@
+/*
+* File Name:	scheduler_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSchedulerPriority(t *testing.T) {
+	s := NewScheduler(1, 0)
+	release, err := s.acquire(context.Background(), PriorityNormal)
+	if err != nil {
+		t.Errorf("acquire failed: %s\n", err)
+		return
+	}
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	for _, p := range []Priority{PriorityBackground, PriorityBackground, PriorityInteractive} {
+		wg.Add(1)
+		go func(p Priority) {
+			defer wg.Done()
+			r, err := s.acquire(context.Background(), p)
+			if err != nil {
+				t.Errorf("acquire failed: %s\n", err)
+				return
+			}
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+			r()
+		}(p)
+		//保证入队顺序
+		time.Sleep(10 * time.Millisecond)
+	}
+	release()
+	wg.Wait()
+	want := []Priority{PriorityInteractive, PriorityBackground, PriorityBackground}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("order: %v, want %v\n", order, want)
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	s := NewScheduler(1, 0)
+	release, _ := s.acquire(context.Background(), PriorityNormal)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.acquire(ctx, PriorityInteractive); err != context.DeadlineExceeded {
+		t.Errorf("acquire: %v, want %v\n", err, context.DeadlineExceeded)
+	}
+	release()
+	r, err := s.acquire(context.Background(), PriorityBackground)
+	if err != nil {
+		t.Errorf("acquire after cancel failed: %s\n", err)
+		return
+	}
+	r()
+}
+
+func TestSchedulerQPS(t *testing.T) {
+	s := NewScheduler(0, 100)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		r, err := s.acquire(context.Background(), PriorityNormal)
+		if err != nil {
+			t.Errorf("acquire failed: %s\n", err)
+			return
+		}
+		r()
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("5 requests at 100 qps took %s\n", elapsed)
+	}
+}
+
+func TestSchedulerBurst(t *testing.T) {
+	y := Init(as, WithRateLimit(10, 3))
+	s := y.scheduler
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		r, err := s.acquire(context.Background(), PriorityNormal)
+		if err != nil {
+			t.Errorf("acquire failed: %s\n", err)
+			return
+		}
+		r()
+		elapsed := time.Since(start)
+		if i < 3 && elapsed > 50*time.Millisecond {
+			t.Errorf("request %d within burst waited %s\n", i, elapsed)
+		}
+		if i == 3 && elapsed < 80*time.Millisecond {
+			t.Errorf("request after burst waited only %s\n", elapsed)
+		}
+	}
+}
+
+func TestSchedulerAdaptive(t *testing.T) {
+	y := Init(as, WithAdaptiveConcurrency(1, 8))
+	s := y.scheduler
+	if s.Limit() != 8 {
+		t.Errorf("initial limit %d, want 8\n", s.Limit())
+	}
+	s.feedback(true)
+	s.feedback(true)
+	if s.Limit() != 4 {
+		t.Errorf("limit after throttle %d, want 4\n", s.Limit())
+	}
+	for i := 0; i < 3; i++ {
+		s.cut = time.Time{}
+		s.feedback(true)
+	}
+	if s.Limit() != 1 {
+		t.Errorf("limit %d, want min 1\n", s.Limit())
+	}
+	//1 -> 2 -> 2.5 -> 2.9
+	for i := 0; i < 3; i++ {
+		s.feedback(false)
+	}
+	if s.Limit() != 2 {
+		t.Errorf("limit after successes %d, want 2\n", s.Limit())
+		return
+	}
+
+	//并发数达到上限时等待
+	r1, _ := s.acquire(context.Background(), PriorityNormal)
+	r2, _ := s.acquire(context.Background(), PriorityNormal)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := s.acquire(ctx, PriorityNormal); err != context.DeadlineExceeded {
+		t.Errorf("acquire over limit: %v\n", err)
+	}
+	r1()
+	r2()
+}
+
+func TestSchedulerOptionsCompose(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithRateLimit(10, 5), WithAdaptiveConcurrency(2, 16)},
+		{WithAdaptiveConcurrency(2, 16), WithRateLimit(10, 5)},
+	} {
+		s := Init(as, opts...).scheduler
+		if s.Limit() != 16 || !s.adaptive || s.interval != 100*time.Millisecond || s.burst != 5 {
+			t.Errorf("scheduler: limit %d, adaptive %t, interval %s, burst %d\n", s.Limit(), s.adaptive, s.interval, s.burst)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+/*
+* File Name:	schema.go
+* Description:  返回数据的schema校验
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+//Violation 返回数据与schema不符的一处记录
+type Violation struct {
+	Interface string //接口名, 如detectface
+	Path      string //字段路径, 如face[0].age
+	Msg       string //不符的原因
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s: %s: %s", v.Interface, v.Path, v.Msg)
+}
+
+//ValidationHook 校验发现不符时的回调, 每次请求最多调用一次
+type ValidationHook func(ifname string, violations []Violation)
+
+//SetValidationHook 开启返回数据的schema校验, 用于及早发现上游接口格式的变化.
+//校验不影响请求的返回结果, 只通过hook报告, hook为nil时关闭校验
+func (y *Youtu) SetValidationHook(hook ValidationHook) {
+	y.validationHook = hook
+}
+
+//schema JSON Schema的一个子集, 支持type, required, properties, items, minimum, maximum
+type schema struct {
+	Type       string             `json:"type"`
+	Required   []string           `json:"required"`
+	Properties map[string]*schema `json:"properties"`
+	Items      *schema            `json:"items"`
+	Minimum    *float64           `json:"minimum"`
+	Maximum    *float64           `json:"maximum"`
+}
+
+const faceSchema = `{
+	"type": "object",
+	"required": ["face_id", "x", "y", "width", "height", "gender", "age", "expression", "glass", "pitch", "yaw", "roll"],
+	"properties": {
+		"face_id":    {"type": "string"},
+		"x":          {"type": "integer"},
+		"y":          {"type": "integer"},
+		"width":      {"type": "number", "minimum": 0},
+		"height":     {"type": "number", "minimum": 0},
+		"gender":     {"type": "integer", "minimum": 0, "maximum": 100},
+		"age":        {"type": "integer", "minimum": 0, "maximum": 100},
+		"expression": {"type": "integer", "minimum": 0, "maximum": 100},
+		"glass":      {"type": "boolean"},
+		"pitch":      {"type": "integer", "minimum": -30, "maximum": 30},
+		"yaw":        {"type": "integer", "minimum": -30, "maximum": 30},
+		"roll":       {"type": "integer", "minimum": -180, "maximum": 180}
+	}
+}`
+
+const stringsSchema = `{"type": "array", "items": {"type": "string"}}`
+
+//rawSchemas 各接口返回数据的schema, 只在errorcode为0时校验
+var rawSchemas = map[string]string{
+	"detectface": `{
+		"type": "object",
+		"required": ["session_id", "image_width", "image_height", "face"],
+		"properties": {
+			"session_id":   {"type": "string"},
+			"image_id":     {"type": "string"},
+			"image_width":  {"type": "integer", "minimum": 0},
+			"image_height": {"type": "integer", "minimum": 0},
+			"face":         {"type": "array", "items": ` + faceSchema + `}
+		}
+	}`,
+	"facecompare": `{
+		"type": "object",
+		"required": ["similarity"],
+		"properties": {
+			"eyebrow_sim": {"type": "number", "minimum": 0, "maximum": 100},
+			"eye_sim":     {"type": "number", "minimum": 0, "maximum": 100},
+			"nose_sim":    {"type": "number", "minimum": 0, "maximum": 100},
+			"mouth_sim":   {"type": "number", "minimum": 0, "maximum": 100},
+			"similarity":  {"type": "number", "minimum": 0, "maximum": 100}
+		}
+	}`,
+	"faceverify": `{
+		"type": "object",
+		"required": ["ismatch", "confidence"],
+		"properties": {
+			"ismatch":    {"type": "boolean"},
+			"confidence": {"type": "number", "minimum": 0, "maximum": 100},
+			"session_id": {"type": "string"}
+		}
+	}`,
+	"faceidentify": `{
+		"type": "object",
+		"required": ["person_id", "face_id", "confidence"],
+		"properties": {
+			"session_id": {"type": "string"},
+			"person_id":  {"type": "string"},
+			"face_id":    {"type": "string"},
+			"confidence": {"type": "number", "minimum": 0, "maximum": 100},
+			"candidates": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"required": ["person_id", "confidence"],
+					"properties": {
+						"person_id":  {"type": "string"},
+						"face_id":    {"type": "string"},
+						"confidence": {"type": "number", "minimum": 0, "maximum": 100},
+						"tag":        {"type": "string"}
+					}
+				}
+			}
+		}
+	}`,
+	"newperson": `{
+		"type": "object",
+		"required": ["person_id", "face_id"],
+		"properties": {
+			"session_id":  {"type": "string"},
+			"suc_group":   {"type": "integer", "minimum": 0},
+			"suc_face":    {"type": "integer", "minimum": 0},
+			"person_name": {"type": "string"},
+			"person_id":   {"type": "string"},
+			"face_id":     {"type": "string"}
+		}
+	}`,
+	"delperson": `{
+		"type": "object",
+		"required": ["deleted"],
+		"properties": {
+			"session_id": {"type": "string"},
+			"deleted":    {"type": "integer", "minimum": 0}
+		}
+	}`,
+	"addface": `{
+		"type": "object",
+		"required": ["added", "face_ids"],
+		"properties": {
+			"session_id": {"type": "string"},
+			"added":      {"type": "integer", "minimum": 0},
+			"face_ids":   ` + stringsSchema + `
+		}
+	}`,
+	"delface": `{
+		"type": "object",
+		"required": ["deleted"],
+		"properties": {
+			"session_id": {"type": "string"},
+			"deleted":    {"type": "integer", "minimum": 0}
+		}
+	}`,
+	"setinfo": `{
+		"type": "object",
+		"required": ["person_id"],
+		"properties": {
+			"session_id": {"type": "string"},
+			"person_id":  {"type": "string"}
+		}
+	}`,
+	"getinfo": `{
+		"type": "object",
+		"required": ["person_id", "group_ids", "face_ids"],
+		"properties": {
+			"person_name": {"type": "string"},
+			"person_id":   {"type": "string"},
+			"group_ids":   ` + stringsSchema + `,
+			"face_ids":    ` + stringsSchema + `
+		}
+	}`,
+	"getgroupids": `{
+		"type": "object",
+		"required": ["group_ids"],
+		"properties": {
+			"group_ids": ` + stringsSchema + `
+		}
+	}`,
+	"getpersonids": `{
+		"type": "object",
+		"required": ["person_ids"],
+		"properties": {
+			"person_ids": ` + stringsSchema + `
+		}
+	}`,
+	"getfaceids": `{
+		"type": "object",
+		"required": ["face_ids"],
+		"properties": {
+			"face_ids": ` + stringsSchema + `
+		}
+	}`,
+	"getfaceinfo": `{
+		"type": "object",
+		"required": ["face_info"],
+		"properties": {
+			"face_info": ` + faceSchema + `
+		}
+	}`,
+}
+
+var (
+	schemasOnce sync.Once
+	schemas     map[string]*schema
+)
+
+func loadSchemas() {
+	schemas = make(map[string]*schema, len(rawSchemas))
+	for ifname, raw := range rawSchemas {
+		s := new(schema)
+		if err := json.Unmarshal([]byte(raw), s); err != nil {
+			panic(fmt.Sprintf("youtu: invalid schema for %s: %s", ifname, err))
+		}
+		schemas[ifname] = s
+	}
+}
+
+//validate 按ifname对应的schema校验body, 没有schema或者errorcode非0时不校验
+func validate(ifname string, body []byte) (vs []Violation) {
+	schemasOnce.Do(loadSchemas)
+	s, ok := schemas[ifname]
+	if !ok {
+		return
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return []Violation{{Interface: ifname, Msg: err.Error()}}
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		if code, ok := m["errorcode"].(float64); ok && code != 0 {
+			return
+		}
+	}
+	s.check(ifname, "", v, &vs)
+	return
+}
+
+func (s *schema) check(ifname, path string, v interface{}, vs *[]Violation) {
+	report := func(format string, args ...interface{}) {
+		p := path
+		if p == "" {
+			p = "."
+		}
+		*vs = append(*vs, Violation{Interface: ifname, Path: p, Msg: fmt.Sprintf(format, args...)})
+	}
+	switch s.Type {
+	case "object":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			report("want object, got %T", v)
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := m[name]; !ok {
+				*vs = append(*vs, Violation{Interface: ifname, Path: join(path, name), Msg: "missing required field"})
+			}
+		}
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if fv, ok := m[name]; ok && fv != nil {
+				s.Properties[name].check(ifname, join(path, name), fv, vs)
+			}
+		}
+	case "array":
+		a, ok := v.([]interface{})
+		if !ok {
+			report("want array, got %T", v)
+			return
+		}
+		if s.Items == nil {
+			return
+		}
+		for i, iv := range a {
+			s.Items.check(ifname, fmt.Sprintf("%s[%d]", path, i), iv, vs)
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			report("want string, got %T", v)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			report("want boolean, got %T", v)
+		}
+	case "number", "integer":
+		f, ok := v.(float64)
+		if !ok {
+			report("want %s, got %T", s.Type, v)
+			return
+		}
+		if s.Type == "integer" && f != math.Trunc(f) {
+			report("want integer, got %v", f)
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			report("%v less than minimum %v", f, *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			report("%v greater than maximum %v", f, *s.Maximum)
+		}
+	}
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
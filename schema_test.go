@@ -0,0 +1,55 @@
+/*
+* File Name:	schema_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import "testing"
+
+func TestValidationHook(t *testing.T) {
+	y, srv := newTestYoutu(`{"session_id":"s","image_width":100,"image_height":100,
+		"face":[{"face_id":"f","x":1,"y":1,"width":10,"height":10,"gender":50,"age":150,
+		"expression":10,"glass":false,"pitch":0,"yaw":0}],"errorcode":0,"errormsg":"OK"}`)
+	defer srv.Close()
+	var got []Violation
+	y.SetValidationHook(func(ifname string, vs []Violation) {
+		got = vs
+	})
+	if _, err := y.DetectFace("image", DetectModeNormal); err != nil {
+		t.Errorf("DetectFace failed: %s\n", err)
+		return
+	}
+	want := map[string]bool{"face[0].age": true, "face[0].roll": true}
+	if len(got) != len(want) {
+		t.Errorf("violations: %v, want paths %v\n", got, want)
+		return
+	}
+	for _, v := range got {
+		if !want[v.Path] {
+			t.Errorf("unexpected violation: %s\n", v)
+		}
+	}
+}
+
+func TestValidationSkipError(t *testing.T) {
+	y, srv := newTestYoutu(`{"errorcode":-1101,"errormsg":"ERROR_PERSON_EXISTED"}`)
+	defer srv.Close()
+	y.SetValidationHook(func(ifname string, vs []Violation) {
+		t.Errorf("unexpected violations: %v\n", vs)
+	})
+	if _, err := y.NewPerson("image", "p", []string{"g"}, "", ""); err != nil {
+		t.Errorf("NewPerson failed: %s\n", err)
+	}
+}
+
+func TestSchemasValid(t *testing.T) {
+	loadSchemas()
+	for ifname := range rawSchemas {
+		if schemas[ifname] == nil {
+			t.Errorf("schema %s not loaded\n", ifname)
+		}
+	}
+}
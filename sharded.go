@@ -0,0 +1,97 @@
+/*
+* File Name:	sharded.go
+* Description:  分片识别
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"sort"
+)
+
+//IdentifyCandidate 识别的候选个体
+type IdentifyCandidate struct {
+	GroupID    string  //识别到该个体的组
+	PersonID   string  //个体ID
+	FaceID     string  //识别的face_id
+	Confidence float32 //接口返回的置信度[0~100]
+	Score      float64 //归一化的得分[0~1], 不同组之间可以比较
+}
+
+//ShardedIdentifyResult 分片识别的结果
+type ShardedIdentifyResult struct {
+	Candidates []IdentifyCandidate //按得分从高到低排列, 同一个体只保留得分最高的一项
+	Errors     map[string]error    //识别失败的组及原因
+}
+
+//Best 返回得分最高的候选, 没有候选时ok为false
+func (r ShardedIdentifyResult) Best() (c IdentifyCandidate, ok bool) {
+	if len(r.Candidates) == 0 {
+		return
+	}
+	return r.Candidates[0], true
+}
+
+//ShardedIdentify 在多个组中并行识别image, 合并各组的结果.
+//单个组的个体数有上限时, 大量个体需要分到多个组中, 每个组返回各自的最佳匹配.
+//部分组失败时失败原因记录在Errors中, 全部失败时返回第一个组的错误. 同时识别的组数为DefaultConcurrency
+func (y *Youtu) ShardedIdentify(image string, groups []string) (res ShardedIdentifyResult, err error) {
+	return y.ShardedIdentifyCtx(context.Background(), image, groups, BatchOptions{})
+}
+
+//ShardedIdentifyCtx 同ShardedIdentify, ctx用于取消请求和设置截止时间, opts.Concurrency为同时识别的组数,
+//应按应用的QPS设置. 因取消或超出预算而未识别的组记录在Errors中
+func (y *Youtu) ShardedIdentifyCtx(ctx context.Context, image string, groups []string, opts BatchOptions) (res ShardedIdentifyResult, err error) {
+	found := make([]IdentifyCandidate, len(groups))
+	errs := make([]error, len(groups))
+	sum := runBatch(ctx, len(groups), opts, func(i int) {
+		fir, err := y.FaceIdentifyCtx(ctx, image, groups[i])
+		if err == nil {
+			err = checkCode("faceidentify", fir.ErrorCode, fir.ErrorMsg)
+		}
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		found[i] = IdentifyCandidate{
+			GroupID:    groups[i],
+			PersonID:   fir.PersonID,
+			FaceID:     fir.FaceID,
+			Confidence: fir.Confidence,
+			Score:      NormalizeConfidence(fir.Confidence),
+		}
+	})
+	for _, i := range sum.Remaining {
+		errs[i] = sum.Err
+	}
+
+	res.Errors = make(map[string]error)
+	best := make(map[string]int)
+	for i, c := range found {
+		if errs[i] != nil {
+			res.Errors[groups[i]] = errs[i]
+			continue
+		}
+		if c.PersonID == "" {
+			continue
+		}
+		if j, ok := best[c.PersonID]; ok {
+			if c.Score > res.Candidates[j].Score {
+				res.Candidates[j] = c
+			}
+			continue
+		}
+		best[c.PersonID] = len(res.Candidates)
+		res.Candidates = append(res.Candidates, c)
+	}
+	sort.SliceStable(res.Candidates, func(i, j int) bool {
+		return res.Candidates[i].Score > res.Candidates[j].Score
+	})
+	if len(groups) > 0 && len(res.Errors) == len(groups) {
+		err = errs[0]
+	}
+	return
+}
@@ -0,0 +1,92 @@
+/*
+* File Name:	sharded_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShardedIdentify(t *testing.T) {
+	identify := map[string]string{
+		"g1": `{"person_id":"alice","face_id":"f1","confidence":71,"errorcode":0}`,
+		"g2": `{"person_id":"bob","face_id":"f2","confidence":93,"errorcode":0}`,
+		"g3": `{"person_id":"alice","face_id":"f3","confidence":85,"errorcode":0}`,
+		"g4": `{"errorcode":-1301,"errormsg":"group not exist"}`,
+	}
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		fmt.Fprint(w, identify[req["group_id"]])
+	})
+	defer srv.Close()
+	res, err := y.ShardedIdentify("image", []string{"g1", "g2", "g3", "g4"})
+	if err != nil {
+		t.Errorf("ShardedIdentify failed: %s\n", err)
+		return
+	}
+	if len(res.Candidates) != 2 {
+		t.Errorf("candidates: %#v\n", res.Candidates)
+		return
+	}
+	best, ok := res.Best()
+	if !ok || best.PersonID != "bob" || best.Score != 0.93 {
+		t.Errorf("best: %#v\n", best)
+	}
+	if c := res.Candidates[1]; c.PersonID != "alice" || c.GroupID != "g3" || c.FaceID != "f3" {
+		t.Errorf("second: %#v\n", c)
+	}
+	if len(res.Errors) != 1 || res.Errors["g4"] == nil {
+		t.Errorf("errors: %v\n", res.Errors)
+	}
+
+	if _, err = y.ShardedIdentify("image", []string{"g4"}); err == nil {
+		t.Errorf("all groups failed but no error\n")
+	}
+}
+
+func TestShardedIdentifyConcurrency(t *testing.T) {
+	var inflight, peak int32
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		fmt.Fprint(w, `{"person_id":"alice","confidence":80,"errorcode":0}`)
+	})
+	defer srv.Close()
+	groups := make([]string, 30)
+	for i := range groups {
+		groups[i] = fmt.Sprintf("g%d", i)
+	}
+	if _, err := y.ShardedIdentify("image", groups); err != nil {
+		t.Errorf("ShardedIdentify failed: %s\n", err)
+	}
+	if p := atomic.LoadInt32(&peak); p > DefaultConcurrency {
+		t.Errorf("%d identify calls in flight, want at most %d\n", p, DefaultConcurrency)
+	}
+	atomic.StoreInt32(&peak, 0)
+	if _, err := y.ShardedIdentifyCtx(context.Background(), "image", groups, BatchOptions{Concurrency: 2}); err != nil {
+		t.Errorf("ShardedIdentifyCtx failed: %s\n", err)
+	}
+	if p := atomic.LoadInt32(&peak); p > 2 {
+		t.Errorf("%d identify calls in flight, want at most 2\n", p)
+	}
+}
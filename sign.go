@@ -0,0 +1,161 @@
+/*
+* File Name:	sign.go
+* Description:  签名方式和单次调用的签名参数
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+)
+
+//SignMode 签名方式
+type SignMode int
+
+const (
+	//SignModeDeveloper 开发者鉴权, 默认的签名方式
+	SignModeDeveloper SignMode = iota
+	//SignModeGeneral 通用鉴权, 签名串中带有bucket
+	SignModeGeneral
+)
+
+func (m SignMode) String() string {
+	switch m {
+	case SignModeDeveloper:
+		return "developer"
+	case SignModeGeneral:
+		return "general"
+	}
+	return fmt.Sprintf("SignMode(%d)", int(m))
+}
+
+//WithSignMode 设置签名方式, bucket为通用鉴权的空间名, 开发者鉴权时忽略
+func WithSignMode(mode SignMode, bucket string) Option {
+	return func(y *Youtu) {
+		y.signMode, y.bucket = mode, bucket
+	}
+}
+
+//SignOverride 单次调用的签名参数, 如多用户网关中以实际操作的用户签名
+type SignOverride struct {
+	UserID  string    //签名的用户ID, 为空时使用AppSign中的用户ID
+	Expired time.Time //签名的过期时间, 为零值时使用客户端的设置
+}
+
+type signOverrideKey struct{}
+
+//ContextWithSignOverride 返回携带签名参数的ctx, 以该ctx调用接口时按o签名
+func ContextWithSignOverride(ctx context.Context, o SignOverride) context.Context {
+	return context.WithValue(ctx, signOverrideKey{}, o)
+}
+
+//signer 返回签名使用的客户端. 设置了CredentialProvider或ctx带有SignOverride时,
+//为使用当前凭证和签名参数的副本
+func (y *Youtu) signer(ctx context.Context) (*Youtu, error) {
+	o, override := ctx.Value(signOverrideKey{}).(SignOverride)
+	if y.credentials == nil && !override {
+		return y, nil
+	}
+	c := *y
+	if y.credentials != nil {
+		as, err := y.credentials.Retrieve()
+		if err != nil {
+			return nil, err
+		}
+		c.appSign = as
+	}
+	if o.UserID != "" {
+		if len(o.UserID) > UserIDMaxLen {
+			return nil, ErrUserIDTooLong
+		}
+		c.appSign.userID = o.UserID
+	}
+	if !o.Expired.IsZero() {
+		c.appSign.expired, c.appSign.ttl, c.signTTL = uint32(o.Expired.Unix()), 0, 0
+	}
+	return &c, nil
+}
+
+//SignAlgorithm 签名算法, 对签名串计算消息认证码
+type SignAlgorithm interface {
+	Name() string
+	Sum(key, msg []byte) []byte
+}
+
+type hmacAlgorithm struct {
+	name string
+	hash func() hash.Hash
+}
+
+func (a hmacAlgorithm) Name() string {
+	return a.name
+}
+
+func (a hmacAlgorithm) Sum(key, msg []byte) []byte {
+	h := hmac.New(a.hash, key)
+	h.Write(msg)
+	return h.Sum(nil)
+}
+
+var (
+	//SignHMACSHA1 HMAC-SHA1, 默认的签名算法
+	SignHMACSHA1 SignAlgorithm = hmacAlgorithm{"HMAC-SHA1", sha1.New}
+	//SignHMACSHA256 HMAC-SHA256, 需要服务端支持
+	SignHMACSHA256 SignAlgorithm = hmacAlgorithm{"HMAC-SHA256", sha256.New}
+)
+
+//WithSignAlgorithm 设置签名算法, alg为nil时使用SignHMACSHA1
+func WithSignAlgorithm(alg SignAlgorithm) Option {
+	return func(y *Youtu) {
+		y.signAlg = alg
+	}
+}
+
+func (y *Youtu) signAlgorithm() SignAlgorithm {
+	if y.signAlg == nil {
+		return SignHMACSHA1
+	}
+	return y.signAlg
+}
+
+//SignInspection 签名的生成过程, 用于与腾讯的签名排查工具对照
+type SignInspection struct {
+	Algorithm     string //签名算法, 如HMAC-SHA1
+	Original      string //签名串, 即签名算法的输入
+	HMAC          string //签名串的消息认证码, 十六进制
+	Authorization string //请求的Authorization
+	SecretKey     string //签名的密钥, 只保留首尾各两位
+}
+
+//InspectSign 生成一个新的签名并返回其生成过程, 使用ctx的SignOverride和CredentialProvider的当前凭证
+func (y *Youtu) InspectSign(ctx context.Context) (si SignInspection, err error) {
+	signer, err := y.signer(ctx)
+	if err != nil {
+		return
+	}
+	alg := signer.signAlgorithm()
+	si.Algorithm = alg.Name()
+	si.Original = signer.orignalSign()
+	si.Authorization = signer.signOriginal(si.Original)
+	si.HMAC = hex.EncodeToString(alg.Sum([]byte(signer.appSign.secretKey), []byte(si.Original)))
+	si.SecretKey = redactSecret(signer.appSign.secretKey)
+	return
+}
+
+//redactSecret 只保留密钥首尾各两位, 过短时全部遮盖
+func redactSecret(s string) string {
+	if len(s) < 8 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:2] + strings.Repeat("*", len(s)-4) + s[len(s)-2:]
+}
@@ -0,0 +1,129 @@
+/*
+* File Name:	sign_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignMode(t *testing.T) {
+	tests := []struct {
+		mode SignMode
+		want string
+	}{
+		{SignModeDeveloper, "a=12345678&k=your_secret_id&e=1436353609&t=1500000000&r=1298498081&u=your_qq_id&f="},
+		{SignModeGeneral, "a=12345678&b=photos&k=your_secret_id&e=1436353609&t=1500000000&r=1298498081&u=your_qq_id&f="},
+	}
+	for _, tt := range tests {
+		y := Init(as, WithHost("localhost"), WithSignMode(tt.mode, "photos"))
+		y.SetClock(fixedClock(time.Unix(1500000000, 0)))
+		y.SetRand(rand.New(rand.NewSource(1)))
+		if got := y.orignalSign(); got != tt.want {
+			t.Errorf("%s: orignalSign %s, want %s\n", tt.mode, got, tt.want)
+		}
+	}
+	if s := SignMode(5).String(); s != "SignMode(5)" {
+		t.Errorf("SignMode(5).String() = %s\n", s)
+	}
+}
+
+func TestSignOverride(t *testing.T) {
+	var signs []string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		raw, _ := base64.StdEncoding.DecodeString(r.Header.Get("Authorization"))
+		signs = append(signs, string(raw[sha1.Size:]))
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+	expired := time.Unix(1600000000, 0)
+	ctx := ContextWithSignOverride(context.Background(), SignOverride{UserID: "alice", Expired: expired})
+	if _, err := y.GetGroupIDsCtx(ctx); err != nil {
+		t.Errorf("GetGroupIDsCtx failed: %s\n", err)
+	}
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs failed: %s\n", err)
+	}
+	if len(signs) != 2 || !strings.Contains(signs[0], "&e=1600000000&") || !strings.HasSuffix(signs[0], "&u=alice&f=") {
+		t.Errorf("overridden sign: %q\n", signs)
+	} else if !strings.Contains(signs[1], "&e=1436353609&") || !strings.HasSuffix(signs[1], "&u=your_qq_id&f=") {
+		t.Errorf("sign after override: %s\n", signs[1])
+	}
+
+	ctx = ContextWithSignOverride(context.Background(), SignOverride{UserID: strings.Repeat("u", UserIDMaxLen+1)})
+	if _, err := y.GetGroupIDsCtx(ctx); err != ErrUserIDTooLong {
+		t.Errorf("long user id: %v, want %v\n", err, ErrUserIDTooLong)
+	}
+}
+
+func TestInspectSign(t *testing.T) {
+	y := Init(as, WithHost("localhost"), WithClock(fixedClock(time.Unix(1500000000, 0))))
+	y.SetRand(rand.New(rand.NewSource(1)))
+	si, err := y.InspectSign(context.Background())
+	if err != nil {
+		t.Fatalf("InspectSign failed: %s\n", err)
+	}
+	y.SetRand(rand.New(rand.NewSource(1)))
+	if want := y.orignalSign(); si.Original != want {
+		t.Errorf("Original: %s, want %s\n", si.Original, want)
+	}
+	y.SetRand(rand.New(rand.NewSource(1)))
+	if want := y.sign(); si.Authorization != want {
+		t.Errorf("Authorization: %s, want %s\n", si.Authorization, want)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(si.Authorization)
+	if len(si.HMAC) != 2*sha1.Size || string(raw[sha1.Size:]) != si.Original {
+		t.Errorf("HMAC %s does not match Authorization\n", si.HMAC)
+	}
+	if si.SecretKey != "yo***********ey" || strings.Contains(fmt.Sprintf("%+v", si), as.secretKey) {
+		t.Errorf("secret key not redacted: %+v\n", si)
+	}
+}
+
+func TestSignAlgorithm(t *testing.T) {
+	y := Init(as, WithHost("localhost"), WithSignAlgorithm(SignHMACSHA256))
+	raw, _ := base64.StdEncoding.DecodeString(y.sign())
+	h := hmac.New(sha256.New, []byte(as.secretKey))
+	h.Write(raw[sha256.Size:])
+	if !hmac.Equal(raw[:sha256.Size], h.Sum(nil)) || !strings.HasPrefix(string(raw[sha256.Size:]), "a=12345678&") {
+		t.Errorf("signature is not HMAC-SHA256 of the original sign\n")
+	}
+	si, err := y.InspectSign(context.Background())
+	if err != nil || si.Algorithm != "HMAC-SHA256" || len(si.HMAC) != 2*sha256.Size {
+		t.Errorf("InspectSign: %+v, %v\n", si, err)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	y := Init(as, WithHost("localhost"))
+	want := `AppSign{appID: 12345678, secretID: yo**********id, secretKey: yo***********ey, expired: 1436353609, userID: "your_qq_id"}`
+	if got := as.Redacted(); got != want {
+		t.Errorf("Redacted: %s, want %s\n", got, want)
+	}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q"} {
+		for _, v := range []interface{}{as, &as, y} {
+			if s := fmt.Sprintf(format, v); strings.Contains(s, as.secretKey) || strings.Contains(s, as.secretID) {
+				t.Errorf("%s leaks secrets: %s\n", format, s)
+			}
+		}
+	}
+	if s := fmt.Sprint(y); s != "Youtu{http://localhost, "+want+"}" {
+		t.Errorf("Youtu.String: %s\n", s)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+* File Name:	signcache.go
+* Description:  签名的缓存和自动续期
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"sync"
+	"time"
+)
+
+//signRefreshMargin 缓存的签名在过期前该时间内不再使用, 留出请求到达服务端的时间
+const signRefreshMargin = time.Minute
+
+//signCache 缓存有效期内的签名, 由同一Init创建的客户端共用
+type signCache struct {
+	mu      sync.Mutex
+	appSign AppSign //生成签名的凭证, 凭证轮换后缓存失效
+	value   string
+	until   time.Time
+}
+
+//WithSignTTL 签名的有效期为生成时刻之后的ttl, 而不是AppSign中固定的过期时间,
+//签名在过期前自动重新生成, 长期运行的服务不会因签名过期而失败. ttl不大于0时使用AppSign的过期时间
+func WithSignTTL(ttl time.Duration) Option {
+	return func(y *Youtu) {
+		y.signTTL = ttl
+	}
+}
+
+//expiry 返回在now生成的签名的过期时间, WithSignTTL优先于AppSign的设置
+func (y *Youtu) expiry(now time.Time) uint32 {
+	switch {
+	case y.signTTL > 0:
+		return uint32(now.Add(y.signTTL).Unix())
+	case y.appSign.ttl > 0:
+		return uint32(now.Add(y.appSign.ttl).Unix())
+	}
+	return y.appSign.expired
+}
+
+//authorization 返回请求的Authorization. 签名设置了有效期时缓存签名,
+//直到过期前signRefreshMargin才重新生成; 有效期为0的签名只能使用一次, 每次生成
+func (y *Youtu) authorization() string {
+	now := y.signTime()
+	expired := y.expiry(now)
+	sc := y.signCache
+	if sc == nil || expired == 0 {
+		return y.sign()
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.value != "" && sc.appSign == y.appSign && now.Before(sc.until) {
+		return sc.value
+	}
+	until := time.Unix(int64(expired), 0).Add(-signRefreshMargin)
+	if !now.Before(until) {
+		return y.sign()
+	}
+	sc.appSign, sc.value, sc.until = y.appSign, y.sign(), until
+	return sc.value
+}
@@ -0,0 +1,85 @@
+/*
+* File Name:	signcache_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignCache(t *testing.T) {
+	sa := as
+	sa.expired = 1500003600
+	y := Init(sa, WithHost("localhost"))
+	clock := &adjustableClock{time.Unix(1500000000, 0)}
+	y.SetClock(clock)
+
+	first := y.authorization()
+	clock.t = clock.t.Add(30 * time.Minute)
+	if got := y.authorization(); got != first {
+		t.Errorf("signature regenerated within validity window\n")
+	}
+	//过期前signRefreshMargin内每次重新生成
+	clock.t = time.Unix(int64(sa.expired), 0).Add(-signRefreshMargin / 2)
+	second := y.authorization()
+	if second == first || y.authorization() == second {
+		t.Errorf("signature reused close to expiry\n")
+	}
+
+	//有效期为0的签名不缓存
+	sa.expired = 0
+	y = Init(sa, WithHost("localhost"))
+	if y.authorization() == y.authorization() {
+		t.Errorf("single-use signature cached\n")
+	}
+}
+
+func TestSignTTL(t *testing.T) {
+	y := Init(as, WithHost("localhost"), WithSignTTL(time.Hour))
+	clock := &adjustableClock{time.Unix(1500000000, 0)}
+	y.SetClock(clock)
+	if s := y.orignalSign(); !strings.Contains(s, "&e=1500003600&") {
+		t.Errorf("orignalSign: %s, want rolling expiry 1500003600\n", s)
+	}
+	if err := y.checkSign(); err != nil {
+		t.Errorf("checkSign with TTL: %s\n", err)
+	}
+	first := y.authorization()
+	clock.t = clock.t.Add(30 * time.Minute)
+	if y.authorization() != first {
+		t.Errorf("signature regenerated within TTL\n")
+	}
+	//过期前重新生成, 新签名的过期时间向后滚动
+	clock.t = clock.t.Add(30 * time.Minute)
+	renewed := y.authorization()
+	if renewed == first || y.authorization() != renewed {
+		t.Errorf("signature not renewed and cached before expiry\n")
+	}
+	if s := y.orignalSign(); !strings.Contains(s, "&e=1500007200&") {
+		t.Errorf("orignalSign after renewal: %s\n", s)
+	}
+}
+
+func TestNewAppSignWithTTL(t *testing.T) {
+	sa, err := NewAppSignWithTTL(12345678, "id", "key", 2*time.Hour, "user")
+	if err != nil {
+		t.Fatalf("NewAppSignWithTTL failed: %s\n", err)
+	}
+	y := Init(sa, WithHost("localhost"), WithClock(fixedClock(time.Unix(1500000000, 0))))
+	if s := y.orignalSign(); !strings.Contains(s, "&e=1500007200&") {
+		t.Errorf("orignalSign: %s, want expiry 2h after signing\n", s)
+	}
+	WithSignTTL(time.Hour)(y)
+	if s := y.orignalSign(); !strings.Contains(s, "&e=1500003600&") {
+		t.Errorf("orignalSign with WithSignTTL: %s\n", s)
+	}
+	if _, err = NewAppSignWithTTL(12345678, "id", "key", 0, "user"); err != ErrTTLInvalid {
+		t.Errorf("zero ttl: %v, want %v\n", err, ErrTTLInvalid)
+	}
+}
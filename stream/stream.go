@@ -0,0 +1,188 @@
+/*
+* File Name:	stream.go
+* Description:  从摄像头视频流中取帧识别
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+//Package stream 从RTSP或MJPEG视频流中按间隔取帧, 调用优图人脸识别并输出带时间戳的识别事件
+package stream
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ochapman/youtu"
+)
+
+//FFmpegPath 打开RTSP流时使用的ffmpeg命令
+var FFmpegPath = "ffmpeg"
+
+//MaxFrameBytes MJPEG流中一帧的最大长度, 默认同youtu.MaxImageURLBytes, 不大于0时不限制
+var MaxFrameBytes int64 = youtu.MaxImageURLBytes
+
+//ErrNoBoundary MJPEG流的Content-Type中没有boundary错误
+var ErrNoBoundary = errors.New("mjpeg stream has no boundary")
+
+//ErrFrameTooLarge MJPEG流中的一帧超过MaxFrameBytes错误, 通常是流的格式错误或boundary不匹配
+var ErrFrameTooLarge = errors.New("mjpeg frame too large")
+
+//Frame 视频流中的一帧
+type Frame struct {
+	Seq  int       //帧序号, 从0开始
+	Time time.Time //读到该帧的时间
+	JPEG []byte    //JPEG编码的图片
+}
+
+//Source 视频帧的来源
+type Source interface {
+	//Next 返回下一帧, 流结束时返回io.EOF
+	Next() (Frame, error)
+	Close() error
+}
+
+//mjpegSource 从multipart/x-mixed-replace格式的流中读取帧
+type mjpegSource struct {
+	mr     *multipart.Reader
+	closer io.Closer
+	seq    int
+}
+
+//NewMJPEGSource 从r中读取以boundary分隔的MJPEG流
+func NewMJPEGSource(r io.ReadCloser, boundary string) Source {
+	return &mjpegSource{mr: multipart.NewReader(r, boundary), closer: r}
+}
+
+func (s *mjpegSource) Next() (f Frame, err error) {
+	part, err := s.mr.NextPart()
+	if err != nil {
+		return
+	}
+	defer part.Close()
+	var r io.Reader = part
+	if MaxFrameBytes > 0 {
+		r = io.LimitReader(part, MaxFrameBytes+1)
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return
+	}
+	if MaxFrameBytes > 0 && int64(len(data)) > MaxFrameBytes {
+		err = ErrFrameTooLarge
+		return
+	}
+	f = Frame{Seq: s.seq, Time: time.Now(), JPEG: data}
+	s.seq++
+	return
+}
+
+func (s *mjpegSource) Close() error {
+	return s.closer.Close()
+}
+
+//OpenMJPEG 打开HTTP MJPEG流, 如网络摄像头的/video.mjpg
+func OpenMJPEG(ctx context.Context, url string) (src Source, err error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		err = fmt.Errorf("get %s failed: %s", url, resp.Status)
+		return
+	}
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || params["boundary"] == "" {
+		resp.Body.Close()
+		return nil, ErrNoBoundary
+	}
+	return NewMJPEGSource(resp.Body, strings.TrimPrefix(params["boundary"], "--")), nil
+}
+
+//ffmpegSource 通过ffmpeg将RTSP流转为MJPEG
+type ffmpegSource struct {
+	Source
+	cmd *exec.Cmd
+}
+
+func (s *ffmpegSource) Close() error {
+	s.Source.Close()
+	s.cmd.Process.Kill()
+	s.cmd.Wait()
+	return nil
+}
+
+//OpenRTSP 通过ffmpeg打开RTSP流, fps为ffmpeg输出的帧率, 不大于0时输出所有帧.
+//需要FFmpegPath指定的ffmpeg可执行
+func OpenRTSP(ctx context.Context, url string, fps int) (src Source, err error) {
+	args := []string{"-loglevel", "error", "-rtsp_transport", "tcp", "-i", url}
+	if fps > 0 {
+		args = append(args, "-r", fmt.Sprint(fps))
+	}
+	args = append(args, "-f", "mpjpeg", "-boundary_tag", "youtuframe", "-")
+	cmd := exec.CommandContext(ctx, FFmpegPath, args...)
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return
+	}
+	if err = cmd.Start(); err != nil {
+		return
+	}
+	return &ffmpegSource{Source: NewMJPEGSource(out, "youtuframe"), cmd: cmd}, nil
+}
+
+//Event 一帧的识别事件
+type Event struct {
+	Seq  int                   //帧序号
+	Time time.Time             //帧的时间
+	Rsp  youtu.FaceIdentifyRsp //识别结果
+	Err  error                 //识别失败的原因, 包括errorcode非0
+}
+
+//Options 取帧识别的选项
+type Options struct {
+	GroupID  string        //识别的组
+	Interval time.Duration //取帧的最小间隔, 间隔内的帧被丢弃, 为0时识别每一帧
+}
+
+//Identify 从src中按opts.Interval取帧, 在opts.GroupID中识别并通过emit输出事件.
+//识别是同步的, 识别期间到达的帧按间隔丢弃. ctx取消或流结束时返回, 流正常结束时返回nil
+func Identify(ctx context.Context, y *youtu.Youtu, src Source, opts Options, emit func(Event)) error {
+	var last time.Time
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		f, err := src.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !last.IsZero() && f.Time.Sub(last) < opts.Interval {
+			continue
+		}
+		last = f.Time
+		ev := Event{Seq: f.Seq, Time: f.Time}
+		ev.Rsp, ev.Err = y.FaceIdentifyCtx(ctx, base64.StdEncoding.EncodeToString(f.JPEG), opts.GroupID)
+		if ev.Err == nil && ev.Rsp.ErrorCode != 0 {
+			ev.Err = &youtu.APIError{Interface: "faceidentify", Code: ev.Rsp.ErrorCode, Msg: ev.Rsp.ErrorMsg}
+		}
+		emit(ev)
+	}
+}
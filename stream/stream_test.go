@@ -0,0 +1,96 @@
+/*
+* File Name:	stream_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/ochapman/youtu"
+)
+
+func TestIdentifyMJPEG(t *testing.T) {
+	cam := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mw := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary="+mw.Boundary())
+		for _, frame := range []string{"alice", "nobody", "bob"} {
+			h := make(textproto.MIMEHeader)
+			h.Set("Content-Type", "image/jpeg")
+			part, _ := mw.CreatePart(h)
+			part.Write([]byte(frame))
+		}
+		mw.Close()
+	}))
+	defer cam.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		frame, _ := base64.StdEncoding.DecodeString(req["image"])
+		if string(frame) == "nobody" {
+			fmt.Fprint(w, `{"errorcode":-1101,"errormsg":"no face"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"person_id":%q,"confidence":90,"errorcode":0}`, frame)
+	}))
+	defer api.Close()
+	as, _ := youtu.NewAppSign(12345678, "id", "key", 0, "user")
+	y := youtu.Init(as, youtu.WithHost(strings.TrimPrefix(api.URL, "http://")))
+
+	src, err := OpenMJPEG(context.Background(), cam.URL)
+	if err != nil {
+		t.Errorf("OpenMJPEG failed: %s\n", err)
+		return
+	}
+	defer src.Close()
+	var events []Event
+	err = Identify(context.Background(), y, src, Options{GroupID: "g"}, func(ev Event) {
+		events = append(events, ev)
+	})
+	if err != nil {
+		t.Errorf("Identify failed: %s\n", err)
+	}
+	if len(events) != 3 {
+		t.Errorf("events: %d, want 3\n", len(events))
+		return
+	}
+	if events[0].Rsp.PersonID != "alice" || events[2].Rsp.PersonID != "bob" || events[2].Seq != 2 {
+		t.Errorf("events: %+v\n", events)
+	}
+	if events[1].Err == nil || events[0].Time.IsZero() {
+		t.Errorf("no face frame: %+v\n", events[1])
+	}
+}
+
+func TestMJPEGFrameTooLarge(t *testing.T) {
+	defer func(max int64) { MaxFrameBytes = max }(MaxFrameBytes)
+	MaxFrameBytes = 8
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, frame := range []string{"12345678", "123456789"} {
+		part, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"image/jpeg"}})
+		part.Write([]byte(frame))
+	}
+	mw.Close()
+	src := NewMJPEGSource(ioutil.NopCloser(&buf), mw.Boundary())
+	if f, err := src.Next(); err != nil || string(f.JPEG) != "12345678" {
+		t.Errorf("frame within the limit: %q, %v\n", f.JPEG, err)
+	}
+	if _, err := src.Next(); err != ErrFrameTooLarge {
+		t.Errorf("frame over the limit: %v, want ErrFrameTooLarge\n", err)
+	}
+}
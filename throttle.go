@@ -0,0 +1,82 @@
+/*
+* File Name:	throttle.go
+* Description:  服务端限流
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//DefaultThrottleDelay 服务端要求限流但没有给出等待时间时的等待时间
+const DefaultThrottleDelay = time.Second
+
+//ThrottleError 服务端要求限流错误: HTTP状态为429或503, 或者errorcode为SetThrottleCodes设置的配额错误码
+type ThrottleError struct {
+	Interface  string        //接口名
+	StatusCode int           //HTTP状态码, errorcode导致时为200
+	ErrorCode  int           //返回状态码, HTTP状态导致时为0
+	RetryAfter time.Duration //重试前应等待的时间
+	RequestID  string        //请求ID
+}
+
+func (e *ThrottleError) Error() string {
+	if e.ErrorCode != 0 {
+		return fmt.Sprintf("%s throttled: errorcode %d, retry after %s%s", e.Interface, e.ErrorCode, e.RetryAfter, requestIDSuffix(e.RequestID))
+	}
+	return fmt.Sprintf("%s throttled: %d %s, retry after %s%s", e.Interface, e.StatusCode, http.StatusText(e.StatusCode), e.RetryAfter, requestIDSuffix(e.RequestID))
+}
+
+//SetThrottleCodes 设置表示超出调用频率或配额的errorcode, 返回这些errorcode时按限流处理.
+//不带参数时清除
+func (y *Youtu) SetThrottleCodes(codes ...int) {
+	y.throttleCodes = make(map[int]bool, len(codes))
+	for _, code := range codes {
+		y.throttleCodes[code] = true
+	}
+}
+
+//throttleCode 返回的errorcode为限流错误码时返回*ThrottleError
+func (y *Youtu) throttleCode(body []byte) error {
+	if len(y.throttleCodes) == 0 {
+		return nil
+	}
+	var r struct {
+		ErrorCode int `json:"errorcode"`
+	}
+	if json.Unmarshal(body, &r) != nil || !y.throttleCodes[r.ErrorCode] {
+		return nil
+	}
+	return &ThrottleError{StatusCode: http.StatusOK, ErrorCode: r.ErrorCode, RetryAfter: DefaultThrottleDelay}
+}
+
+//throttled 记录限流的接口, 并暂停调度器发出新的请求, 避免立即重试
+func (y *Youtu) throttled(ifname, requestID string, te *ThrottleError) {
+	te.Interface, te.RequestID = ifname, requestID
+	if y.scheduler != nil {
+		y.scheduler.pause(te.RetryAfter)
+	}
+}
+
+//parseRetryAfter 解析Retry-After头, 支持秒数和HTTP日期两种格式, 无法解析时返回DefaultThrottleDelay
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	v = strings.TrimSpace(v)
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return DefaultThrottleDelay
+}
@@ -0,0 +1,72 @@
+/*
+* File Name:	throttle_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	cases := []struct {
+		v    string
+		want time.Duration
+	}{
+		{"3", 3 * time.Second},
+		{"Thu, 15 Oct 2026 08:00:05 GMT", 5 * time.Second},
+		{"Thu, 15 Oct 2026 07:59:00 GMT", 0},
+		{"", DefaultThrottleDelay},
+		{"soon", DefaultThrottleDelay},
+	}
+	for _, c := range cases {
+		if got := parseRetryAfter(c.v, now); got != c.want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s\n", c.v, got, c.want)
+		}
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	status := http.StatusTooManyRequests
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"errorcode":-1699}`))
+	})
+	defer srv.Close()
+	s := NewScheduler(0, 0)
+	y.SetScheduler(s)
+	_, err := y.GetGroupIDs()
+	te, ok := err.(*ThrottleError)
+	if !ok || te.Interface != "getgroupids" || te.StatusCode != 429 || te.RetryAfter != time.Second {
+		t.Errorf("GetGroupIDs: %#v\n", err)
+	}
+	//暂停期间新的请求需要等待
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err = s.acquire(ctx, PriorityInteractive); err != context.DeadlineExceeded {
+		t.Errorf("acquire during pause: %v\n", err)
+	}
+
+	status = http.StatusOK
+	y.SetScheduler(nil)
+	if _, err = y.GetGroupIDs(); err != nil {
+		t.Errorf("errorcode without throttle codes: %v\n", err)
+	}
+	y.SetThrottleCodes(-1699)
+	if _, err = y.GetGroupIDs(); err == nil {
+		t.Errorf("throttle code not detected\n")
+	} else if te, ok = err.(*ThrottleError); !ok || te.ErrorCode != -1699 || te.RetryAfter != DefaultThrottleDelay {
+		t.Errorf("throttle code: %#v\n", err)
+	}
+}
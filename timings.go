@@ -0,0 +1,97 @@
+/*
+* File Name:	timings.go
+* Description:  请求各阶段的耗时
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+//Timings 一次请求各阶段的耗时, 复用连接时DNS, Connect和TLSHandshake为0
+type Timings struct {
+	DNS          time.Duration //域名解析
+	Connect      time.Duration //建立TCP连接
+	TLSHandshake time.Duration //TLS握手
+	TTFB         time.Duration //从获取连接到收到响应的第一个字节
+	Reused       bool          //是否复用了空闲连接
+}
+
+type timingsKey struct{}
+
+//ContextWithTimings 返回记录请求各阶段耗时的ctx, 以该ctx调用接口后, t中为最后一次请求的耗时.
+//基于net/http/httptrace, ctx中已有的httptrace.ClientTrace同样生效, 也可以直接以
+//httptrace.WithClientTrace返回的ctx调用接口
+func ContextWithTimings(ctx context.Context, t *Timings) context.Context {
+	var (
+		start, dnsStart, tlsStart time.Time
+		mu                        sync.Mutex
+		connectStart              map[string]time.Time //Happy Eyeballs时同时连接多个地址, 按network和addr记录
+		connected                 bool
+	)
+	ctx = context.WithValue(ctx, timingsKey{}, t)
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GetConn: func(string) {
+			mu.Lock()
+			defer mu.Unlock()
+			*t = Timings{}
+			start = time.Now()
+			connectStart, connected = nil, false
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.Reused = info.Reused
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.DNS = time.Since(dnsStart)
+		},
+		ConnectStart: func(network, addr string) {
+			mu.Lock()
+			defer mu.Unlock()
+			if connectStart == nil {
+				connectStart = make(map[string]time.Time)
+			}
+			connectStart[network+" "+addr] = time.Now()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			//只记录第一个成功的连接, 失败的和落选的连接不覆盖, 落选的连接可能在请求结束后才完成
+			mu.Lock()
+			defer mu.Unlock()
+			if begin, ok := connectStart[network+" "+addr]; ok && err == nil && !connected {
+				connected = true
+				t.Connect = time.Since(begin)
+			}
+		},
+		TLSHandshakeStart: func() {
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.TLSHandshake = time.Since(tlsStart)
+		},
+		GotFirstResponseByte: func() {
+			t.TTFB = time.Since(start)
+		},
+	})
+}
+
+//noTraceContext 屏蔽ctx中的httptrace.ClientTrace, 其它值、截止时间和取消不变
+type noTraceContext struct {
+	context.Context
+}
+
+func (c noTraceContext) Value(key interface{}) interface{} {
+	v := c.Context.Value(key)
+	if _, ok := v.(*httptrace.ClientTrace); ok {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,84 @@
+/*
+* File Name:	timings_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTimings(t *testing.T) {
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+	var tm Timings
+	gotConn := 0
+	ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+		GotConn: func(httptrace.GotConnInfo) { gotConn++ },
+	})
+	ctx = ContextWithTimings(ctx, &tm)
+	if _, err := y.GetGroupIDsCtx(ctx); err != nil {
+		t.Errorf("GetGroupIDsCtx failed: %s\n", err)
+	}
+	if tm.Reused || tm.Connect <= 0 || tm.TTFB < tm.Connect {
+		t.Errorf("first request timings: %+v\n", tm)
+	}
+	if _, err := y.GetGroupIDsCtx(ctx); err != nil {
+		t.Errorf("GetGroupIDsCtx failed: %s\n", err)
+	}
+	if !tm.Reused || tm.Connect != 0 || tm.TTFB <= 0 {
+		t.Errorf("second request timings: %+v\n", tm)
+	}
+	if gotConn != 2 {
+		t.Errorf("caller's ClientTrace called %d times, want 2\n", gotConn)
+	}
+}
+
+func TestTimingsParallelDial(t *testing.T) {
+	var tm Timings
+	trace := httptrace.ContextClientTrace(ContextWithTimings(context.Background(), &tm))
+	trace.GetConn("api.youtu.qq.com:443")
+	//Happy Eyeballs: IPv6和IPv4的连接并发进行, IPv6失败, IPv4成功
+	var wg sync.WaitGroup
+	for _, addr := range []string{"[2001:db8::1]:443", "192.0.2.1:443"} {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			trace.ConnectStart("tcp", addr)
+		}(addr)
+	}
+	wg.Wait()
+	time.Sleep(10 * time.Millisecond)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		trace.ConnectDone("tcp", "192.0.2.1:443", nil)
+	}()
+	go func() {
+		defer wg.Done()
+		trace.ConnectDone("tcp", "[2001:db8::1]:443", errors.New("connect: network is unreachable"))
+	}()
+	wg.Wait()
+	connect := tm.Connect
+	if connect < 10*time.Millisecond {
+		t.Errorf("Connect: %s, want the IPv4 dial's duration\n", connect)
+	}
+	//落选的连接在之后完成, 不覆盖
+	trace.ConnectDone("tcp", "[2001:db8::1]:443", nil)
+	if tm.Connect != connect {
+		t.Errorf("Connect overwritten by a later dial: %s, want %s\n", tm.Connect, connect)
+	}
+}
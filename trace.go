@@ -0,0 +1,28 @@
+/*
+* File Name:	trace.go
+* Description:  调用追踪
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import "context"
+
+//Tracer 为每次接口调用创建span, 返回的ctx用于发送请求, 使span成为调用方span的子span.
+//OpenTelemetry的实现见github.com/ochapman/youtu/youtuotel
+type Tracer interface {
+	Start(ctx context.Context, ifname string) (context.Context, Span)
+}
+
+//Span 一次接口调用的span, 调用结束时以调用的记录结束. e.Duration为整个调用的耗时, 包括排队
+type Span interface {
+	End(e LogEvent)
+}
+
+//WithTracer 设置调用追踪, 默认不追踪
+func WithTracer(t Tracer) Option {
+	return func(y *Youtu) {
+		y.tracer = t
+	}
+}
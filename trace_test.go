@@ -0,0 +1,60 @@
+/*
+* File Name:	trace_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"testing"
+)
+
+type spanKey struct{}
+
+type testTracer struct {
+	started []string
+	ended   []LogEvent
+}
+
+func (t *testTracer) Start(ctx context.Context, ifname string) (context.Context, Span) {
+	t.started = append(t.started, ifname)
+	return context.WithValue(ctx, spanKey{}, ifname), testSpan{t}
+}
+
+type testSpan struct {
+	t *testTracer
+}
+
+func (s testSpan) End(e LogEvent) {
+	s.t.ended = append(s.t.ended, e)
+}
+
+func TestTracer(t *testing.T) {
+	y, srv := newTestYoutu(`{"errorcode":-1101,"errormsg":"group not exist"}`)
+	defer srv.Close()
+	tr := &testTracer{}
+	var inSpan interface{}
+	WithTracer(tr)(y)
+	WithInterceptors(func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, call *Call) ([]byte, error) {
+			inSpan = ctx.Value(spanKey{})
+			return next(ctx, call)
+		}
+	})(y)
+	if _, err := y.GetPersonIDs("g"); err != nil {
+		t.Errorf("GetPersonIDs failed: %s\n", err)
+	}
+	if len(tr.started) != 1 || tr.started[0] != "getpersonids" || len(tr.ended) != 1 {
+		t.Errorf("spans started %v, ended %d\n", tr.started, len(tr.ended))
+		return
+	}
+	if inSpan != "getpersonids" {
+		t.Errorf("request context not derived from span context\n")
+	}
+	if e := tr.ended[0]; e.Interface != "getpersonids" || e.StatusCode != 200 || e.ErrorCode != -1101 || e.Err != nil {
+		t.Errorf("span event: %+v\n", e)
+	}
+}
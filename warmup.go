@@ -0,0 +1,116 @@
+/*
+* File Name:	warmup.go
+* Description:  预先建立连接
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+)
+
+//warmupState Init时开始的后台预热
+type warmupState struct {
+	done chan struct{}
+	err  error
+}
+
+//WithWarmup 在Init时后台调用Warmup, 可以用WaitWarmup等待其完成.
+//probe为true时预热后再调用一次GetGroupIDs, 同时检查凭证, 对之后的请求预热服务端
+func WithWarmup(probe bool) Option {
+	return func(y *Youtu) {
+		y.warmupProbe = probe
+		y.warmup = &warmupState{done: make(chan struct{})}
+	}
+}
+
+//startWarmup 在后台预热, Close时取消
+func (y *Youtu) startWarmup() {
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutNormal.duration())
+	y.lifecycle.onClose(cancel)
+	go func() {
+		defer cancel()
+		y.warmup.err = y.Warmup(ctx)
+		close(y.warmup.done)
+	}()
+}
+
+//WaitWarmup 等待WithWarmup开始的后台预热完成, 返回预热的结果. 没有设置WithWarmup时返回nil
+func (y *Youtu) WaitWarmup(ctx context.Context) error {
+	if y.warmup == nil {
+		return nil
+	}
+	select {
+	case <-y.warmup.done:
+		return y.warmup.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//Warmup 预先解析服务地址并建立保持连接(https时包括TLS握手), 使启动后的第一个请求不必等待建立连接.
+//设置了WithDNSCache时解析结果存入缓存, 只设置了WithDialer时不解析服务地址.
+//设置了调度器时建立与并发数上限相同的连接, 否则建立一个.
+//服务端对预热请求的返回状态不影响结果, 只有无法解析或连接时返回错误. WithWarmup(true)时还返回GetGroupIDs的错误
+func (y *Youtu) Warmup(ctx context.Context) error {
+	host, _, err := net.SplitHostPort(y.host)
+	if err != nil {
+		host, err = y.host, nil
+	}
+	switch {
+	case net.ParseIP(host) != nil:
+	case y.dnsCache != nil:
+		_, err = y.dnsCache.LookupHost(ctx, host)
+	case y.dial == nil:
+		_, err = net.DefaultResolver.LookupHost(ctx, host)
+	}
+	if err != nil {
+		return err
+	}
+	conns := 1
+	if y.scheduler != nil {
+		if limit := y.scheduler.Limit(); limit > 1 {
+			conns = limit
+		}
+	}
+	errs := make(chan error, conns)
+	for i := 0; i < conns; i++ {
+		go func() {
+			errs <- warmupConn(ctx, y.client, y.baseURL()+"/", y.userAgent)
+		}()
+	}
+	for i := 0; i < conns; i++ {
+		if e := <-errs; e != nil && err == nil {
+			err = e
+		}
+	}
+	if err != nil || !y.warmupProbe {
+		return err
+	}
+	ggr, err := y.GetGroupIDsCtx(ctx)
+	if err == nil {
+		err = checkCode("getgroupids", int(ggr.ErrorCode), ggr.ErrorMsg)
+	}
+	return err
+}
+
+//warmupConn 发送一个HEAD请求并读完返回, 使连接回到空闲连接池中
+func warmupConn(ctx context.Context, client *http.Client, url, userAgent string) error {
+	req, err := http.NewRequest("HEAD", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	return resp.Body.Close()
+}
@@ -0,0 +1,78 @@
+/*
+* File Name:	warmup_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtu
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWarmup(t *testing.T) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errorcode":0}`))
+	}))
+	srv.Config.ConnState = func(c net.Conn, s http.ConnState) {
+		if s == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+	y := Init(as, WithHost(strings.TrimPrefix(srv.URL, "http://")))
+	tr := &http.Transport{}
+	defer tr.CloseIdleConnections()
+	y.SetTransport(tr)
+	if err := y.Warmup(context.Background()); err != nil {
+		t.Errorf("Warmup failed: %s\n", err)
+	}
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Errorf("connections after Warmup: %d, want 1\n", n)
+	}
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs failed: %s\n", err)
+	}
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Errorf("request did not reuse the warm connection: %d connections\n", n)
+	}
+
+	bad := Init(as, WithHost("nonexistent.invalid"))
+	if err := bad.Warmup(context.Background()); err == nil {
+		t.Errorf("Warmup of unresolvable host succeeded\n")
+	}
+}
+
+func TestWithWarmup(t *testing.T) {
+	var paths []string
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(`{"errorcode":0}`))
+	}))
+	defer srv.Close()
+	y := Init(as, WithHost(strings.TrimPrefix(srv.URL, "http://")), WithWarmup(true))
+	if err := y.WaitWarmup(context.Background()); err != nil {
+		t.Errorf("WaitWarmup failed: %s\n", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) != 2 || paths[0] != "HEAD /" || paths[1] != "POST /youtu/api/getgroupids" {
+		t.Errorf("warmup requests: %q\n", paths)
+	}
+	if err := Init(as).WaitWarmup(context.Background()); err != nil {
+		t.Errorf("WaitWarmup without WithWarmup: %s\n", err)
+	}
+}
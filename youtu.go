@@ -8,14 +8,15 @@
 package youtu
 
 import (
-	"crypto/hmac"
-	"crypto/sha1"
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"math/rand"
 	"net/http"
 	"strconv"
 	"strings"
@@ -25,11 +26,25 @@ import (
 const (
 	//UserIDMaxLen 用户ID的最大长度
 	UserIDMaxLen = 110
+	//Version SDK的版本
+	Version = "0.2.0"
+	//DefaultUserAgent 默认的User-Agent
+	DefaultUserAgent = "youtu-go/" + Version
 )
 
 var (
 	//ErrUserIDTooLong 用户ID过长错误
 	ErrUserIDTooLong = errors.New("user id too long")
+	//ErrAppIDRequired 没有设置app_id
+	ErrAppIDRequired = errors.New("app id required")
+	//ErrSecretIDRequired 没有设置secret_id
+	ErrSecretIDRequired = errors.New("secret id required")
+	//ErrSecretKeyRequired 没有设置secret_key
+	ErrSecretKeyRequired = errors.New("secret key required")
+	//ErrExpiredInPast 签名的过期时间已过, expired应为UNIX时间戳而不是有效期的秒数
+	ErrExpiredInPast = errors.New("expired is in the past")
+	//ErrTTLInvalid 签名的有效期不大于0
+	ErrTTLInvalid = errors.New("ttl must be positive")
 )
 
 var (
@@ -39,17 +54,29 @@ var (
 
 //AppSign 应用签名鉴权
 type AppSign struct {
-	appID     uint32 //接入优图服务时,生成的唯一id, 用于唯一标识接入业务
-	secretID  string //标识api鉴权调用者的密钥身份
-	secretKey string //用于加密签名字符串和服务器端验证签名字符串的密钥，secret_key 必须严格保管避免泄露
-	expired   uint32 //此签名的凭证有效期，是一个符合UNIX Epoch时间戳规范的数值，单位为秒, e应大于t, 生成的签名在 t 到 e 的时间内 都是有效的. 如果是0, 则生成的签名只有再t的时刻是有效的
-	userID    string //接入业务自行定义的用户id，用于唯一标识一个用户, 登陆开发者账号的QQ号码
+	appID     uint32        //接入优图服务时,生成的唯一id, 用于唯一标识接入业务
+	secretID  string        //标识api鉴权调用者的密钥身份
+	secretKey string        //用于加密签名字符串和服务器端验证签名字符串的密钥，secret_key 必须严格保管避免泄露
+	expired   uint32        //此签名的凭证有效期，是一个符合UNIX Epoch时间戳规范的数值，单位为秒, e应大于t, 生成的签名在 t 到 e 的时间内 都是有效的. 如果是0, 则生成的签名只有再t的时刻是有效的
+	userID    string        //接入业务自行定义的用户id，用于唯一标识一个用户, 登陆开发者账号的QQ号码
+	ttl       time.Duration //签名的有效期, 不为0时过期时间为签名时刻之后的ttl, 忽略expired
 }
 
-//NewAppSign 新建应用签名
+//NewAppSign 新建应用签名. appID, secretID和secretKey必须设置, expired为0或未来的UNIX时间戳
 func NewAppSign(appID uint32, secretID string, secretKey string, expired uint32, userID string) (as AppSign, err error) {
-	if len(userID) > UserIDMaxLen {
+	switch {
+	case appID == 0:
+		err = ErrAppIDRequired
+	case secretID == "":
+		err = ErrSecretIDRequired
+	case secretKey == "":
+		err = ErrSecretKeyRequired
+	case expired != 0 && int64(expired) <= time.Now().Unix():
+		err = ErrExpiredInPast
+	case len(userID) > UserIDMaxLen:
 		err = ErrUserIDTooLong
+	}
+	if err != nil {
 		return
 	}
 	as = AppSign{
@@ -62,22 +89,134 @@ func NewAppSign(appID uint32, secretID string, secretKey string, expired uint32,
 	return
 }
 
-//Youtu 存储签名和host
+//NewAppSignWithTTL 新建有效期为ttl的应用签名, 过期时间为每次签名时刻之后的ttl,
+//签名在过期前自动重新生成. 效果同WithSignTTL
+func NewAppSignWithTTL(appID uint32, secretID string, secretKey string, ttl time.Duration, userID string) (as AppSign, err error) {
+	if ttl <= 0 {
+		err = ErrTTLInvalid
+		return
+	}
+	if as, err = NewAppSign(appID, secretID, secretKey, 0, userID); err != nil {
+		return
+	}
+	as.ttl = ttl
+	return
+}
+
+//Redacted 返回遮盖了secretID和secretKey的描述, 只保留首尾各两位, 用于日志和诊断
+func (as AppSign) Redacted() string {
+	expiry := strconv.FormatUint(uint64(as.expired), 10)
+	if as.ttl > 0 {
+		expiry = "ttl " + as.ttl.String()
+	}
+	return fmt.Sprintf("AppSign{appID: %d, secretID: %s, secretKey: %s, expired: %s, userID: %q}",
+		as.appID, redactSecret(as.secretID), redactSecret(as.secretKey), expiry, as.userID)
+}
+
+//String 同Redacted, 以%v或%+v输出时不会泄露密钥
+func (as AppSign) String() string {
+	return as.Redacted()
+}
+
+//GoString 同Redacted, 以%#v输出时不会泄露密钥
+func (as AppSign) GoString() string {
+	return as.Redacted()
+}
+
+//Youtu 存储签名和host. 可以被多个goroutine同时使用, 签名、重试和调度都是并发安全的;
+//Set开头的方法修改客户端本身, 应在开始调用接口前完成设置
 type Youtu struct {
-	appSign AppSign
-	host    string
+	appSign          AppSign
+	host             string
+	scheme           string
+	timeout          time.Duration
+	validationHook   ValidationHook
+	preCheck         *PreCheck
+	dryRun           DryRunFunc
+	audit            *AuditLog
+	auditReason      string
+	journal          *Journal
+	scheduler        *Scheduler
+	priority         Priority
+	client           *http.Client
+	ownTransport     *http.Transport //客户端自己创建的Transport, Close时关闭其空闲连接
+	transport        transportConfig
+	transportErr     error //连接设置无法应用到自定义RoundTripper时的错误
+	preprocess       PreprocessChain
+	postprocess      PostProcessChain
+	clock            Clock
+	random           Rand
+	clockSkew        time.Duration
+	fixedSign        *fixedSign //WithFixedSign固定的签名时间戳和随机数
+	signCache        *signCache
+	credentials      CredentialProvider
+	signTTL          time.Duration
+	signMode         SignMode
+	signAlg          SignAlgorithm
+	bucket           string
+	lifecycle        *lifecycle
+	throttleCodes    map[int]bool
+	retry            RetryPolicy
+	breaker          *Breaker
+	interceptors     []Interceptor
+	debug            *debugLog
+	logger           Logger
+	tracer           Tracer
+	gzipMinSize      int
+	maxResponseBytes int64
+	dial             DialFunc
+	dnsCache         *DNSCache
+	userAgent        string
+	warmupProbe      bool
+	warmup           *warmupState
+	hedgeDelay       time.Duration
+	hedgeHost        string
 }
 
 func (y *Youtu) appID() string {
 	return strconv.Itoa(int(y.appSign.appID))
 }
 
-//Init Youtu初始化
-func Init(appSign AppSign, host string) *Youtu {
-	return &Youtu{
-		appSign: appSign,
-		host:    host,
+//Init Youtu初始化, 默认以http访问DefaultHost, 可以通过opts修改
+func Init(appSign AppSign, opts ...Option) *Youtu {
+	y := &Youtu{
+		appSign:          appSign,
+		host:             DefaultHost,
+		scheme:           "http",
+		client:           new(http.Client),
+		priority:         PriorityNormal,
+		lifecycle:        new(lifecycle),
+		signCache:        new(signCache),
+		maxResponseBytes: DefaultMaxResponseBytes,
+		userAgent:        DefaultUserAgent,
+	}
+	for _, opt := range opts {
+		opt(y)
+	}
+	y.buildTransport()
+	if y.warmup != nil {
+		y.startWarmup()
 	}
+	return y
+}
+
+//WithAppSign 返回以appSign签名的客户端副本, 用于服务多个租户时按请求切换凭证.
+//副本与y共用连接、调度器和熔断器等全部设置, 需要按租户限流时使用ClientManager
+func (y *Youtu) WithAppSign(appSign AppSign) *Youtu {
+	c := *y
+	c.appSign = appSign
+	c.signCache = new(signCache)
+	return &c
+}
+
+//String 返回客户端的服务地址和遮盖了密钥的凭证
+func (y *Youtu) String() string {
+	return fmt.Sprintf("Youtu{%s, %s}", y.baseURL(), y.appSign.Redacted())
+}
+
+//GoString 同String, 以%#v输出时不会泄露密钥
+func (y *Youtu) GoString() string {
+	return y.String()
 }
 
 //DetectMode 检测模式，分正常和大脸
@@ -90,12 +229,6 @@ const (
 	DetectModeBigFace
 )
 
-type detectFaceReq struct {
-	AppID string     `json:"app_id"`         //App的 API ID
-	Image string     `json:"image"`          //base64编码的二进制图片数据
-	Mode  DetectMode `json:"mode,omitempty"` //检测模式 0/1 正常/大脸模式
-}
-
 //Face 脸参数
 type Face struct {
 	FaceID     string  `json:"face_id"`    //人脸标识
@@ -123,25 +256,6 @@ type DetectFaceRsp struct {
 	ErrorMsg    string `json:"errormsg"`     //返回错误消息
 }
 
-//DetectFace 检测给定图片(Image)中的所有人脸(Face)的位置和相应的面部属性。
-//位置包括(x, y, w, h)，面部属性包括性别(gender), 年龄(age),
-//表情(expression), 眼镜(glass)和姿态(pitch，roll，yaw).
-func (y *Youtu) DetectFace(imageData string, mode DetectMode) (dfr DetectFaceRsp, err error) {
-	req := detectFaceReq{
-		AppID: strconv.Itoa(int(y.appSign.appID)),
-		Image: imageData,
-		Mode:  mode,
-	}
-	err = y.interfaceRequest("detectface", req, &dfr)
-	return
-}
-
-type faceCompareReq struct {
-	AppID  string `json:"app_id"`
-	ImageA string `json:"imageA"` //使用base64编码的二进制图片数据A
-	ImageB string `json:"imageB"` //使用base64编码的二进制图片数据B
-}
-
 //FaceCompareRsp 脸比较返回
 type FaceCompareRsp struct {
 	EyebrowSim float32 `json:"eyebrow_sim"` //眉毛的相似度。
@@ -153,23 +267,6 @@ type FaceCompareRsp struct {
 	ErrorMsg   string  `json:"errormsg"`    //返回错误消息
 }
 
-//FaceCompare 计算两个Face的相似性以及五官相似度
-func (y *Youtu) FaceCompare(imageA, imageB string) (fcr FaceCompareRsp, err error) {
-	req := faceCompareReq{
-		AppID:  y.appID(),
-		ImageA: imageA,
-		ImageB: imageB,
-	}
-	err = y.interfaceRequest("facecompare", req, &fcr)
-	return
-}
-
-type faceVerifyReq struct {
-	AppID    string `json:"app_id"`    //App的 API ID
-	Image    string `json:"image"`     //使用base64编码的二进制图片数据
-	PersonID string `json:"person_id"` //待验证的Person
-}
-
 //FaceVerifyRsp 脸验证返回
 type FaceVerifyRsp struct {
 	Ismatch    bool    `json:"ismatch"`    //两个输入是否为同一人的判断
@@ -179,51 +276,47 @@ type FaceVerifyRsp struct {
 	ErrorMsg   string  `json:"errormsg"`   //返回错误消息
 }
 
-//FaceVerify 给定一个Face和一个Person，返回是否是同一个人的判断以及置信度。
-func (y *Youtu) FaceVerify(image string, personID string) (fvr FaceVerifyRsp, err error) {
-	req := faceVerifyReq{
-		AppID:    y.appID(),
-		Image:    image,
-		PersonID: personID,
-	}
-	err = y.interfaceRequest("faceverify", req, &fvr)
-	return
-}
-
-type faceIdentifyReq struct {
-	AppID   string `json:"app_id"`   //App的 API ID
-	GroupID string `json:"group_id"` //候选人组id
-	Image   string `json:"image"`    //使用base64编码的二进制图片数据
+//Candidate 脸识别的一个候选人
+type Candidate struct {
+	PersonID   string  `json:"person_id"`  //候选人的person_id
+	FaceID     string  `json:"face_id"`    //最相似的face_id
+	Confidence float32 `json:"confidence"` //置信度
+	Tag        string  `json:"tag"`        //候选人的备注信息
 }
 
 //FaceIdentifyRsp 脸识别返回
 type FaceIdentifyRsp struct {
-	SessionID  string  `json:"session_id"` //相应请求的session标识符，可用于结果查询
-	PersonID   string  `json:"person_id"`  //识别结果，person_id
-	FaceID     string  `json:"face_id"`    //识别的face_id
-	Confidence float32 `json:"confidence"` //置信度
-	ErrorCode  int     `json:"errorcode"`  //返回状态码
-	ErrorMsg   string  `json:"errormsg"`   //返回错误消息
+	SessionID  string      `json:"session_id"` //相应请求的session标识符，可用于结果查询
+	PersonID   string      `json:"person_id"`  //识别结果，person_id
+	FaceID     string      `json:"face_id"`    //识别的face_id
+	Confidence float32     `json:"confidence"` //置信度
+	Candidates []Candidate `json:"candidates"` //候选人, 按置信度从高到低
+	ErrorCode  int         `json:"errorcode"`  //返回状态码
+	ErrorMsg   string      `json:"errormsg"`   //返回错误消息
 }
 
-//FaceIdentify 对于一个待识别的人脸图片，在一个Group中识别出最相似的Person作为其身份返回
-func (y *Youtu) FaceIdentify(image string, groupID string) (fir FaceIdentifyRsp, err error) {
-	req := faceIdentifyReq{
-		AppID:   y.appID(),
-		GroupID: groupID,
-		Image:   image,
-	}
-	err = y.interfaceRequest("faceidentify", req, &fir)
-	return
+//FaceRect 人脸框
+type FaceRect struct {
+	X      int32 `json:"x"`      //左上角x
+	Y      int32 `json:"y"`      //左上角y
+	Width  int32 `json:"width"`  //宽度
+	Height int32 `json:"height"` //高度
+}
+
+//FaceIdentifyResult 多人脸识别中一个人脸的识别结果
+type FaceIdentifyResult struct {
+	FaceRect   FaceRect    `json:"face_rect"`  //人脸框
+	Candidates []Candidate `json:"candidates"` //候选人, 按置信度从高到低
+	ErrorCode  int         `json:"errorcode"`  //该人脸的识别状态码
+	ErrorMsg   string      `json:"errormsg"`   //该人脸的识别错误消息
 }
 
-type newPersonReq struct {
-	AppID      string   `json:"app_id"` //App的 API ID
-	Image      string   `json:"image"`  //使用base64编码的二进制图片数据
-	PersonID   string   `json:"person_id"`
-	GroupIDs   []string `json:"group_ids"`             // 	加入到组的列表
-	PersonName string   `json:"person_name,omitempty"` //名字
-	Tag        string   `json:"tag,omitempty"`         //备注信息
+//MultiFaceIdentifyRsp 多人脸识别返回
+type MultiFaceIdentifyRsp struct {
+	SessionID string               `json:"session_id"` //相应请求的session标识符
+	Results   []FaceIdentifyResult `json:"results"`    //各人脸的识别结果
+	ErrorCode int                  `json:"errorcode"`  //返回状态码
+	ErrorMsg  string               `json:"errormsg"`   //返回错误消息
 }
 
 //NewPersonRsp 个体创建返回
@@ -238,25 +331,6 @@ type NewPersonRsp struct {
 	ErrorMsg   string `json:"errormsg"`    //返回错误消息
 }
 
-//NewPerson 创建一个Person，并将Person放置到group_ids指定的组当中
-func (y *Youtu) NewPerson(image string, personID string, groupIDs []string, personName string, tag string) (npr NewPersonRsp, err error) {
-	req := newPersonReq{
-		AppID:      y.appID(),
-		PersonID:   personID,
-		Image:      image,
-		GroupIDs:   groupIDs,
-		PersonName: personName,
-		Tag:        tag,
-	}
-	err = y.interfaceRequest("newperson", req, &npr)
-	return
-}
-
-type delPersonReq struct {
-	AppID    string `json:"app_id"`
-	PersonID string `json:"person_id"` //待删除个体ID
-}
-
 //DelPersonRsp 删除个体返回
 type DelPersonRsp struct {
 	SessionID string `json:"session_id"` //相应请求的session标识符
@@ -265,23 +339,6 @@ type DelPersonRsp struct {
 	ErrorMsg  string `json:"errormsg"`   //返回错误消息
 }
 
-//DelPerson 删除一个Person
-func (y *Youtu) DelPerson(personID string) (dpr DelPersonRsp, err error) {
-	req := delPersonReq{
-		AppID:    y.appID(),
-		PersonID: personID,
-	}
-	err = y.interfaceRequest("delperson", req, &dpr)
-	return
-}
-
-type addFaceReq struct {
-	AppID    string   `json:"app_id"`        //App的 API ID
-	PersonID string   `json:"person_id"`     //String 	待增加人脸的个体id
-	Images   []string `json:"images"`        //base64编码的二进制图片数据构成的数组
-	Tag      string   `json:"tag,omitempty"` //备注信息
-}
-
 //AddFaceRsp 增加人脸返回
 type AddFaceRsp struct {
 	SessionID string   `json:"session_id"` //相应请求的session标识符
@@ -291,25 +348,6 @@ type AddFaceRsp struct {
 	ErrorMsg  string   `json:"errormsg"`   //返回错误消息
 }
 
-//AddFace 将一组Face加入到一个Person中。注意，一个Face只能被加入到一个Person中。
-//一个Person最多允许包含10000个Face
-func (y *Youtu) AddFace(images []string, personID string, tag string) (afr AddFaceRsp, err error) {
-	req := addFaceReq{
-		AppID:    y.appID(),
-		Images:   images,
-		PersonID: personID,
-		Tag:      tag,
-	}
-	err = y.interfaceRequest("addface", req, &afr)
-	return
-}
-
-type delFaceReq struct {
-	AppID    string   `json:"app_id"`    //App的 API ID
-	PersonID string   `json:"person_id"` //待删除人脸的person ID
-	FaceIDs  []string `json:"face_ids"`  //删除人脸id的列表
-}
-
 //DelFaceRsp 删除人脸返回
 type DelFaceRsp struct {
 	SessonID  string `json:"session_id"` //相应请求的session标识符
@@ -318,53 +356,19 @@ type DelFaceRsp struct {
 	ErrorMsg  string `json:"errormsg"`   //返回错误消息
 }
 
-//DelFace 删除一个person下的face，包括特征，属性和face_id.
-func (y *Youtu) DelFace(personID string, faceIDs []string) (dfr DelFaceRsp, err error) {
-	req := delFaceReq{
-		AppID:    y.appID(),
-		PersonID: personID,
-		FaceIDs:  faceIDs,
-	}
-	err = y.interfaceRequest("delface", req, &dfr)
-	return
-}
-
-type setInfoReq struct {
-	AppID      string `json:"app_id"` //App的 API ID
-	PersonID   string `json:"person_id"`
-	PersonName string `json:"person_name,omitempty"` //新的name
-	Tag        string `json:"tag,omitempty"`         //备注信息
-}
-
 //SetInfoRsp 设置信息返回
 type SetInfoRsp struct {
-	sessionID string `json:"session_id"` //相应请求的session标识符
-	personID  string `json:"person_id"`  //相应person的id
-	errorcode int32  `json:"errorcode"`  //返回状态码
-	errormsg  string `json:"errormsg"`   //返回错误消息
-}
-
-//SetInfo 设置Person的name.
-func (y *Youtu) SetInfo(personID string, personName string, tag string) (sir SetInfoRsp, err error) {
-	req := setInfoReq{
-		AppID:      y.appID(),
-		PersonID:   personID,
-		PersonName: personName,
-		Tag:        tag,
-	}
-	err = y.interfaceRequest("setinfo", req, &sir)
-	return
-}
-
-type getInfoReq struct {
-	AppID    string `json:"app_id"`    //App的 API ID
-	PersonID string `json:"person_id"` //待查询个体的ID
+	SessionID string `json:"session_id"` //相应请求的session标识符
+	PersonID  string `json:"person_id"`  //相应person的id
+	ErrorCode int32  `json:"errorcode"`  //返回状态码
+	ErrorMsg  string `json:"errormsg"`   //返回错误消息
 }
 
 //GetInfoRsp 获取信息返回
 type GetInfoRsp struct {
 	PersonName string   `json:"person_name"` //相应person的name
 	PersonID   string   `json:"person_id"`   //相应person的id
+	Tag        string   `json:"tag"`         //备注信息
 	GroupIDs   []string `json:"group_ids"`   //包含此个体的组列表
 	FaceIDs    []string `json:"face_ids"`    //包含的人脸列表
 	SessionID  string
@@ -372,20 +376,6 @@ type GetInfoRsp struct {
 	ErrorMsg   string `json:"errormsg"`  //返回错误消息
 }
 
-//GetInfo 获取一个Person的信息, 包括name, id, tag, 相关的face, 以及groups等信息。
-func (y *Youtu) GetInfo(personID string) (gir GetInfoRsp, err error) {
-	req := getInfoReq{
-		AppID:    y.appID(),
-		PersonID: personID,
-	}
-	err = y.interfaceRequest("getinfo", req, &gir)
-	return
-}
-
-type getGroupIDsReq struct {
-	AppID string `json:"app_id"` //App的 API ID
-}
-
 //GetGroupIDsRsp 获取组ID返回
 type GetGroupIDsRsp struct {
 	GroupIDs  []string `json:"group_ids"` //相应app_id的group_id列表
@@ -393,20 +383,6 @@ type GetGroupIDsRsp struct {
 	ErrorMsg  string   `json:"errormsg"`  //返回错误消息
 }
 
-//GetGroupIDs 获取一个appId下所有group列表
-func (y *Youtu) GetGroupIDs() (ggr GetGroupIDsRsp, err error) {
-	req := getGroupIDsReq{
-		AppID: y.appID(),
-	}
-	err = y.interfaceRequest("getgroupids", req, &ggr)
-	return
-}
-
-type getPersonIDsReq struct {
-	AppID   string `json:"app_id"`   //App的 API ID
-	GroupID string `json:"group_id"` //组id
-}
-
 //GetPersonIDsRsp 获取个人ID返回
 type GetPersonIDsRsp struct {
 	PersonIDs []string `json:"person_ids"` //相应person的id列表
@@ -414,21 +390,6 @@ type GetPersonIDsRsp struct {
 	ErrorMsg  string   `json:"errormsg"`   //返回错误消息
 }
 
-//GetPersonIDs 获取一个组Group中所有person列表
-func (y *Youtu) GetPersonIDs(groupID string) (gpr GetPersonIDsRsp, err error) {
-	req := getPersonIDsReq{
-		AppID:   y.appID(),
-		GroupID: groupID,
-	}
-	err = y.interfaceRequest("getpersonids", req, &gpr)
-	return
-}
-
-type getFaceIDsReq struct {
-	AppID    string `json:"app_id"`    //App的 API ID
-	PersonID string `json:"person_id"` //个体id
-}
-
 //GetFaceIDsRsp 获取脸ID返回
 type GetFaceIDsRsp struct {
 	FaceIDs   []string `json:"face_ids"`  //相应face的id列表
@@ -436,21 +397,6 @@ type GetFaceIDsRsp struct {
 	ErrorMsg  string   `json:"errormsg"`  //返回错误消息
 }
 
-//GetFaceIDs 获取一个组person中所有face列表
-func (y *Youtu) GetFaceIDs(personID string) (gfr GetFaceIDsRsp, err error) {
-	req := getFaceIDsReq{
-		AppID:    y.appID(),
-		PersonID: personID,
-	}
-	err = y.interfaceRequest("getfaceids", req, &gfr)
-	return
-}
-
-type getFaceInfoReq struct {
-	AppID  string `json:"app_id"`  //App的 API ID
-	FaceID string `json:"face_id"` //人脸id
-}
-
 //GetFaceInfoRsp 获取脸部信息返回
 type GetFaceInfoRsp struct {
 	FaceInfo  Face   `json:"face_info"` //人脸信息
@@ -458,50 +404,166 @@ type GetFaceInfoRsp struct {
 	ErrorMsg  string `json:"errormsg"`  //返回错误消息
 }
 
-//GetFaceInfo 获取一个face的相关特征信息
-func (y *Youtu) GetFaceInfo(faceID string) (gfr GetFaceInfoRsp, err error) {
-	req := getFaceInfoReq{
-		AppID:  y.appID(),
-		FaceID: faceID,
-	}
-	err = y.interfaceRequest("getfaceinfo", req, &gfr)
-	return
+//FacePoint 五官定位的轮廓点
+type FacePoint struct {
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+}
+
+//FaceShape 一个人脸的五官轮廓, 各部位为按顺序连接的轮廓点
+type FaceShape struct {
+	FaceProfile  []FacePoint `json:"face_profile"`  //脸型轮廓, 21个点
+	LeftEye      []FacePoint `json:"left_eye"`      //左眼轮廓, 8个点
+	RightEye     []FacePoint `json:"right_eye"`     //右眼轮廓, 8个点
+	LeftEyebrow  []FacePoint `json:"left_eyebrow"`  //左眉轮廓, 8个点
+	RightEyebrow []FacePoint `json:"right_eyebrow"` //右眉轮廓, 8个点
+	Mouth        []FacePoint `json:"mouth"`         //嘴巴轮廓, 22个点
+	Nose         []FacePoint `json:"nose"`          //鼻子轮廓, 13个点
+}
+
+//FaceShapeRsp 五官定位返回
+type FaceShapeRsp struct {
+	SessionID   string      `json:"session_id"`   //相应请求的session标识符
+	FaceShape   []FaceShape `json:"face_shape"`   //各人脸的五官轮廓
+	ImageWidth  int32       `json:"image_width"`  //请求图片的宽度
+	ImageHeight int32       `json:"image_height"` //请求图片的高度
+	ErrorCode   int         `json:"errorcode"`    //返回状态码
+	ErrorMsg    string      `json:"errormsg"`     //返回错误消息
+}
+
+func (y *Youtu) baseURL() string {
+	return y.scheme + "://" + y.host
 }
 
 func (y *Youtu) interfaceURL(ifname string) string {
-	return fmt.Sprintf("http://%s/youtu/api/%s", y.host, ifname)
+	return y.interfaceURLAt(y.host, ifname)
 }
 
-func (y *Youtu) interfaceRequest(ifname string, req, rsp interface{}) (err error) {
+func (y *Youtu) interfaceURLAt(host, ifname string) string {
+	return fmt.Sprintf("%s://%s/youtu/%s/%s", y.scheme, host, lookupEndpoint(ifname).family, ifname)
+}
+
+func (y *Youtu) interfaceRequest(ctx context.Context, ifname string, req, rsp interface{}) (err error) {
 	url := y.interfaceURL(ifname)
-	//fmt.Printf("req: %#v\n", req)
+	ep := lookupEndpoint(ifname)
+	if y.transportErr != nil {
+		return y.transportErr
+	}
+	if err = y.lifecycle.begin(); err != nil {
+		return
+	}
+	defer y.lifecycle.end()
+	ctx = withRequestID(ctx)
+	var (
+		body []byte
+		call *Call
+	)
+	if y.tracer != nil {
+		var span Span
+		ctx, span = y.tracer.Start(ctx, ifname)
+		start := y.now()
+		defer func() { span.End(y.callEvent(ctx, ifname, start, call, body, err)) }()
+	}
+	//breaker, 调度和请求前的检查失败时同样记录日志, 耗时从发送请求开始计算, 不包括排队
+	start := y.now()
+	if y.logger != nil {
+		defer func() { y.logger.LogCall(ctx, y.callEvent(ctx, ifname, start, call, body, err)) }()
+	}
+	if req, err = y.preprocessRequest(req); err != nil {
+		return
+	}
+	if err = y.preCheckRequest(req); err != nil {
+		return
+	}
 	data, err := json.Marshal(req)
 	if err != nil {
 		return
 	}
-	body, err := y.get(url, string(data))
-	if err != nil {
+	if ep.mutating && y.dryRun != nil {
+		y.dryRun(DryRunCall{Interface: ifname, Request: data})
 		return
 	}
-	//fmt.Println("body: ", string(body))
-	err = json.Unmarshal(body, &rsp)
+	var undo *InverseOp
+	if ep.mutating && y.journal != nil {
+		if undo, err = y.prepareUndo(ctx, req); err != nil {
+			return
+		}
+	}
+	if y.breaker != nil {
+		if err = y.breaker.allow(ifname, y.now()); err != nil {
+			return
+		}
+	}
+	if y.scheduler != nil {
+		release, err := y.scheduler.acquire(ctx, y.priority)
+		if err != nil {
+			if y.breaker != nil {
+				y.breaker.done(ctx, ifname, err, y.now())
+			}
+			return err
+		}
+		defer release()
+	}
+	timeout := ep.timeout.duration()
+	if y.timeout != 0 {
+		timeout = y.timeout
+	}
+	start = y.now()
+	//不需要原始响应时直接从连接解码, 省去中间的缓冲
+	var decode interface{}
+	info := callInfoFrom(ctx)
+	if !y.rawBody(ifname) && info == nil {
+		decode = rsp
+	}
+	body, call, err = y.send(ctx, ifname, url, data, decode, timeout)
+	if info != nil {
+		info.fill(ctx, call, body)
+	}
+	if y.breaker != nil {
+		y.breaker.done(ctx, ifname, err, y.now())
+	}
+	if y.scheduler != nil && (err == nil || overloaded(err)) {
+		y.scheduler.feedback(err != nil)
+	}
+	if ep.mutating && y.audit != nil {
+		y.auditCall(ifname, data, body, err)
+	}
 	if err != nil {
-		return fmt.Errorf("json.Unmarshal() rsp: %s failed: %s\n", rsp, err)
+		return
+	}
+	if decode == nil {
+		err = json.Unmarshal(body, &rsp)
+		if err != nil {
+			return fmt.Errorf("json.Unmarshal() rsp: %s failed: %s\n", rsp, err)
+		}
 	}
-	//fmt.Printf("rsp: %#v\n", rsp)
+	if ep.mutating && y.journal != nil {
+		y.journal.record(req, rsp, undo)
+	}
+	if y.validationHook != nil {
+		if vs := validate(ifname, body); len(vs) > 0 {
+			y.validationHook(ifname, vs)
+		}
+	}
+	err = y.postprocess.Apply(ifname, rsp)
 	return
 }
 
+//orignalSign 返回签名串, 通用鉴权的签名串在a之后带有bucket
 func (y *Youtu) orignalSign() string {
 	as := y.appSign
-	now := time.Now().Unix()
-	rand.Seed(int64(now))
-	rnd := rand.Int31()
-	return fmt.Sprintf("a=%d&k=%s&e=%d&t=%d&r=%d&u=%s&f=",
+	now := y.signTime()
+	rnd := y.nonce()
+	bucket := ""
+	if y.signMode == SignModeGeneral {
+		bucket = "&b=" + y.bucket
+	}
+	return fmt.Sprintf("a=%d%s&k=%s&e=%d&t=%d&r=%d&u=%s&f=",
 		as.appID,
+		bucket,
 		as.secretID,
-		as.expired,
-		now,
+		y.expiry(now),
+		now.Unix(),
 		rnd,
 		as.userID)
 }
@@ -516,35 +578,212 @@ func EncodeImage(file string) (imgData string, err error) {
 	return
 }
 
+//MaxImageURLBytes EncodeImageURL下载的图片大小上限
+const MaxImageURLBytes = 10 << 20
+
+//imageURLClient 下载图片的客户端, 各次下载共用连接
+var imageURLClient = &http.Client{Timeout: 10 * time.Second}
+
+//EncodeImageURL 下载url指定的图片并编码, 同EncodeImageURLCtx
+func EncodeImageURL(url string) (imgData string, err error) {
+	return EncodeImageURLCtx(context.Background(), url)
+}
+
+//EncodeImageURLCtx 下载url指定的图片并编码. 响应须为200, 大小不超过MaxImageURLBytes.
+//Content-Type不是image/*时(如缺失或为binary/octet-stream)按内容的前512字节判断是否为图片. ctx用于取消下载
+func EncodeImageURLCtx(ctx context.Context, url string) (imgData string, err error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return
+	}
+	resp, err := imageURLClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("get %s failed: %s", url, resp.Status)
+		return
+	}
+	if resp.ContentLength > MaxImageURLBytes {
+		err = fmt.Errorf("get %s failed: image larger than %d bytes", url, MaxImageURLBytes)
+		return
+	}
+	br := bufio.NewReaderSize(io.LimitReader(resp.Body, MaxImageURLBytes+1), 512)
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "image/") {
+		//读取错误由之后的ReadAll返回
+		head, _ := br.Peek(512)
+		if sniffed := http.DetectContentType(head); !strings.HasPrefix(sniffed, "image/") {
+			err = fmt.Errorf("get %s failed: content type %q (%s) is not an image", url, ct, sniffed)
+			return
+		}
+	}
+	buf, err := ioutil.ReadAll(br)
+	if err != nil {
+		return
+	}
+	if len(buf) > MaxImageURLBytes {
+		err = fmt.Errorf("get %s failed: image larger than %d bytes", url, MaxImageURLBytes)
+		return
+	}
+	imgData = base64.StdEncoding.EncodeToString(buf)
+	return
+}
+
+//EncodeImageURLs 下载并编码一组url指定的图片, 同EncodeImageURLsCtx, 并发数为DefaultConcurrency
+func EncodeImageURLs(urls []string) (imgData []string, err error) {
+	return EncodeImageURLsCtx(context.Background(), urls, BatchOptions{})
+}
+
+//EncodeImageURLsCtx 按opts的并发数下载并编码一组url指定的图片, 结果顺序与urls一致.
+//任一图片失败时返回第一个错误, ctx取消或超出预算时返回BatchSummary.Err
+func EncodeImageURLsCtx(ctx context.Context, urls []string, opts BatchOptions) (imgData []string, err error) {
+	imgData = make([]string, len(urls))
+	errs := make([]error, len(urls))
+	sum := runBatch(ctx, len(urls), opts, func(i int) {
+		imgData[i], errs[i] = EncodeImageURLCtx(ctx, urls[i])
+	})
+	for _, e := range errs {
+		if e != nil {
+			return nil, e
+		}
+	}
+	if sum.Err != nil {
+		return nil, sum.Err
+	}
+	return
+}
+
+//sign 生成请求的签名, 只读取不可变的appSign, 可以并发调用
 func (y *Youtu) sign() string {
-	origSign := y.orignalSign()
-	h := hmac.New(sha1.New, []byte(y.appSign.secretKey))
-	h.Write([]byte(origSign))
-	hm := h.Sum(nil)
+	return y.signOriginal(y.orignalSign())
+}
+
+//signOriginal 以secretKey对签名串origSign签名
+func (y *Youtu) signOriginal(origSign string) string {
+	hm := y.signAlgorithm().Sum([]byte(y.appSign.secretKey), []byte(origSign))
 	//attach orig_sign to hm
 	dstSign := []byte(string(hm) + origSign)
 	b64 := base64.StdEncoding.EncodeToString(dstSign)
 	return b64
 }
 
-func (y *Youtu) get(addr string, req string) (rsp []byte, err error) {
-	client := &http.Client{
-		Timeout: time.Duration(5 * time.Second),
+//SetHTTPClient 设置发送请求的http.Client, 用于自定义的transport、代理、监控等.
+//c的Timeout与接口的超时同时生效. c为nil时恢复默认的http.Client
+func (y *Youtu) SetHTTPClient(c *http.Client) {
+	if c == nil {
+		c = new(http.Client)
+	}
+	y.client = c
+	y.ownTransport, y.transportErr = nil, nil
+}
+
+//SetTransport 设置发送请求的http.RoundTripper, 多个客户端可以共用以复用连接.
+//t为nil时使用http.DefaultTransport
+func (y *Youtu) SetTransport(t http.RoundTripper) {
+	y.client = &http.Client{Transport: t}
+	y.ownTransport, y.transportErr = nil, nil
+}
+
+func (y *Youtu) get(ctx context.Context, addr string, call *Call, timeout time.Duration) (rsp []byte, err error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	reqBody, gzipped, err := y.requestBody(call.Payload)
+	if err != nil {
+		return
 	}
-	httpreq, err := http.NewRequest("POST", addr, strings.NewReader(req))
+	//以bytes.Reader构造请求, http.NewRequest会设置GetBody, 连接被关闭时transport可以重发请求体
+	httpreq, err := http.NewRequest("POST", addr, bytes.NewReader(reqBody))
 	if err != nil {
 		return
 	}
-	httpreq.Header.Add("Authorization", y.sign())
+	signer, err := y.signer(ctx)
+	if err != nil {
+		return
+	}
+	httpreq.Header.Add("Authorization", signer.authorization())
 	httpreq.Header.Add("Content-Type", "text/json")
-	httpreq.Header.Add("User-Agent", "")
+	httpreq.Header.Add("User-Agent", y.userAgent)
 	httpreq.Header.Add("Accept", "*/*")
+	httpreq.Header.Add("Accept-Encoding", "gzip")
 	httpreq.Header.Add("Expect", "100-continue")
-	resp, err := client.Do(httpreq)
+	if gzipped {
+		httpreq.Header.Add("Content-Encoding", "gzip")
+	}
+	for k, v := range call.Header {
+		httpreq.Header[k] = v
+	}
+	var resp *http.Response
+	if y.debug != nil {
+		y.debug.request(httpreq, call.Payload)
+		defer func() { y.debug.response(resp, rsp, err) }()
+	}
+	resp, err = y.client.Do(httpreq.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer resp.Body.Close()
-	rsp, err = ioutil.ReadAll(resp.Body)
+	call.StatusCode, call.ResponseHeader = resp.StatusCode, resp.Header
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		err = &ThrottleError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), y.now())}
+		return
+	}
+	body, err := responseBody(resp)
+	if err != nil {
+		return
+	}
+	if max := y.maxResponseBytes; max > 0 {
+		if resp.ContentLength > max {
+			err = &ResponseTooLargeError{Limit: max}
+			return
+		}
+		body = &limitedReader{r: body, remaining: max, err: &ResponseTooLargeError{Limit: max}}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := ioutil.ReadAll(io.LimitReader(body, maxErrorBody))
+		se := &StatusError{StatusCode: resp.StatusCode, Body: strings.ToValidUTF8(string(snippet), "")}
+		if date, e := http.ParseTime(resp.Header.Get("Date")); e == nil && se.Auth() {
+			se.ClockSkew = y.signTime().Sub(date)
+		}
+		err = se
+		return
+	}
+	if call.decode != nil {
+		err = json.NewDecoder(body).Decode(call.decode)
+		return
+	}
+	rsp, err = ioutil.ReadAll(body)
+	return
+}
+
+//rawBody 是否需要完整的响应体: 调试、限流错误码、审计、字段校验和拦截器需要原始响应,
+//对冲的两个请求不能同时解码到同一结构
+func (y *Youtu) rawBody(ifname string) bool {
+	return y.debug != nil || len(y.throttleCodes) > 0 || (lookupEndpoint(ifname).mutating && y.audit != nil) ||
+		y.validationHook != nil || len(y.interceptors) > 0 || y.hedging(ifname)
+}
+
+//limitedReader 读取超过remaining字节时返回err
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	err       error
+}
+
+func (l *limitedReader) Read(p []byte) (n int, err error) {
+	if l.remaining < 0 {
+		return 0, l.err
+	}
+	//多读一个字节以判断是否超出
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err = l.r.Read(p)
+	if l.remaining -= int64(n); l.remaining < 0 {
+		return n + int(l.remaining), l.err
+	}
 	return
 }
@@ -7,7 +7,27 @@
 
 package youtu
 
-import "testing"
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
 
 //Update as if you want to test your own app
 var as = AppSign{
@@ -18,7 +38,20 @@ var as = AppSign{
 	userID:    "your_qq_id",
 }
 
-var yt = Init(as, DefaultHost)
+var yt = Init(as)
+
+//newTestServer 返回一个请求发往本地stub的Youtu
+func newTestServer(h http.HandlerFunc) (*Youtu, *httptest.Server) {
+	srv := httptest.NewServer(h)
+	return Init(as, WithHost(strings.TrimPrefix(srv.URL, "http://"))), srv
+}
+
+//newTestYoutu 返回一个请求发往本地stub的Youtu, stub对所有接口返回rsp
+func newTestYoutu(rsp string) (*Youtu, *httptest.Server) {
+	return newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, rsp)
+	})
+}
 
 func TestDetectFace(t *testing.T) {
 	imgData, err := EncodeImage("testdata/imageA.jpg")
@@ -193,3 +226,591 @@ func TestGetFaceInfo(t *testing.T) {
 	}
 	t.Logf("gfr: %#v\n", gfr)
 }
+
+func TestEncodeImageURLs(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer srv.Close()
+	urls := []string{srv.URL + "/imageA.jpg", srv.URL + "/imageB.jpg"}
+	imgData, err := EncodeImageURLs(urls)
+	if err != nil {
+		t.Errorf("EncodeImageURLs failed: %s\n", err)
+		return
+	}
+	for i, file := range []string{"testdata/imageA.jpg", "testdata/imageB.jpg"} {
+		want, err := EncodeImage(file)
+		if err != nil {
+			t.Errorf("EncodeImage failed: %s\n", err)
+			return
+		}
+		if imgData[i] != want {
+			t.Errorf("imgData[%d] mismatch with %s\n", i, file)
+		}
+	}
+	if _, err = EncodeImageURLs([]string{srv.URL + "/notexist.jpg"}); err == nil {
+		t.Errorf("EncodeImageURLs should fail for missing image\n")
+	}
+}
+
+func TestEncodeImageURLLimits(t *testing.T) {
+	var inflight, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		case "/octet.jpg", "/untyped.jpg":
+			if r.URL.Path == "/octet.jpg" {
+				w.Header().Set("Content-Type", "binary/octet-stream")
+			} else {
+				w.Header()["Content-Type"] = nil
+			}
+			w.Write([]byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"))
+		case "/octet.html":
+			w.Header().Set("Content-Type", "binary/octet-stream")
+			w.Write([]byte("<html></html>"))
+		case "/huge.jpg":
+			w.Header().Set("Content-Type", "image/jpeg")
+			chunk := make([]byte, 1<<20)
+			for i := 0; i <= MaxImageURLBytes>>20; i++ {
+				if _, err := w.Write(chunk); err != nil {
+					return
+				}
+			}
+		default:
+			n := atomic.AddInt32(&inflight, 1)
+			defer atomic.AddInt32(&inflight, -1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			w.Header().Set("Content-Type", "image/jpeg")
+			w.Write([]byte("jpeg"))
+		}
+	}))
+	defer srv.Close()
+
+	if _, err := EncodeImageURL(srv.URL + "/page.html"); err == nil {
+		t.Errorf("EncodeImageURL accepted text/html\n")
+	}
+	//Content-Type不是image/*时按内容判断, 如对象存储常见的binary/octet-stream
+	for _, path := range []string{"/octet.jpg", "/untyped.jpg"} {
+		if data, err := EncodeImageURL(srv.URL + path); err != nil || data != "/9j/4AAQSkZJRgA=" {
+			t.Errorf("EncodeImageURL %s: %q, %v\n", path, data, err)
+		}
+	}
+	if _, err := EncodeImageURL(srv.URL + "/octet.html"); err == nil {
+		t.Errorf("EncodeImageURL accepted HTML served as binary/octet-stream\n")
+	}
+	if _, err := EncodeImageURL(srv.URL + "/huge.jpg"); err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Errorf("EncodeImageURL of oversized image: %v\n", err)
+	}
+	urls := make([]string, 20)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("%s/%d.jpg", srv.URL, i)
+	}
+	imgData, err := EncodeImageURLsCtx(context.Background(), urls, BatchOptions{Concurrency: 3})
+	if err != nil || len(imgData) != len(urls) || imgData[19] != "anBlZw==" {
+		t.Errorf("EncodeImageURLsCtx: %v\n", err)
+	}
+	if p := atomic.LoadInt32(&peak); p > 3 {
+		t.Errorf("%d downloads in flight, want at most 3\n", p)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = EncodeImageURLsCtx(ctx, urls, BatchOptions{}); err == nil {
+		t.Errorf("EncodeImageURLsCtx with canceled ctx succeeded\n")
+	}
+}
+
+func TestEnrollByURL(t *testing.T) {
+	var req map[string]interface{}
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		req = nil
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Write([]byte(`{"errorcode":0,"person_id":"p","face_ids":["f1","f2"]}`))
+	})
+	defer srv.Close()
+	if _, err := y.NewPersonURL("http://example.com/a.jpg", "p", []string{"g"}, "name", ""); err != nil {
+		t.Errorf("NewPersonURL failed: %s\n", err)
+	}
+	if _, ok := req["image"]; ok || req["url"] != "http://example.com/a.jpg" || req["person_id"] != "p" {
+		t.Errorf("newperson request: %v\n", req)
+	}
+	afr, err := y.AddFaceURLs([]string{"http://example.com/b.jpg", "http://example.com/c.jpg"}, "p", "")
+	if err != nil || len(afr.FaceIDs) != 2 {
+		t.Errorf("AddFaceURLs: %+v, %v\n", afr, err)
+	}
+	if urls, _ := req["urls"].([]interface{}); len(urls) != 2 || urls[1] != "http://example.com/c.jpg" {
+		t.Errorf("addface request: %v\n", req)
+	}
+	if _, ok := req["images"]; ok {
+		t.Errorf("addface request with urls sent images: %v\n", req)
+	}
+}
+
+func TestRequestCtx(t *testing.T) {
+	unblock := make(chan struct{})
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-unblock:
+		case <-r.Context().Done():
+		}
+		fmt.Fprint(w, `{"errorcode":0}`)
+	})
+	defer srv.Close()
+	defer close(unblock)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := y.FaceIdentifyCtx(ctx, "image", "g")
+	if err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Errorf("FaceIdentifyCtx: %v, want deadline exceeded\n", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("FaceIdentifyCtx returned after %s\n", d)
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = y.ShardedIdentifyCtx(cancelled, "image", []string{"g1", "g2"}, BatchOptions{}); err != context.Canceled {
+		t.Errorf("ShardedIdentifyCtx: %v, want %v\n", err, context.Canceled)
+	}
+}
+
+func TestSharedClient(t *testing.T) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		//读完请求, 否则服务端会关闭连接
+		ioutil.ReadAll(r.Body)
+		fmt.Fprint(w, `{"errorcode":0}`)
+	}))
+	srv.Config.ConnState = func(c net.Conn, s http.ConnState) {
+		if s == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+	y := Init(as, WithHost(strings.TrimPrefix(srv.URL, "http://")))
+	for i := 0; i < 3; i++ {
+		if _, err := y.GetGroupIDs(); err != nil {
+			t.Errorf("GetGroupIDs failed: %s\n", err)
+		}
+	}
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Errorf("connections: %d, want 1\n", n)
+	}
+}
+
+func TestSetHTTPClient(t *testing.T) {
+	y, srv := newTestYoutu(`{"errorcode":0}`)
+	defer srv.Close()
+	ct := &countingTransport{}
+	y.SetHTTPClient(&http.Client{Transport: ct})
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs failed: %s\n", err)
+	}
+	if ct.n != 1 {
+		t.Errorf("custom client saw %d requests, want 1\n", ct.n)
+	}
+	y.SetHTTPClient(nil)
+	if _, err := y.GetGroupIDs(); err != nil || ct.n != 1 {
+		t.Errorf("default client: %v, custom client requests %d\n", err, ct.n)
+	}
+}
+
+func TestInitOptions(t *testing.T) {
+	y := Init(as)
+	if y.baseURL() != "http://"+DefaultHost {
+		t.Errorf("default baseURL: %s\n", y.baseURL())
+	}
+	y = Init(as, WithHost("example.com:8443"), WithScheme("https"))
+	if y.baseURL() != "https://example.com:8443" {
+		t.Errorf("baseURL: %s, want https://example.com:8443\n", y.baseURL())
+	}
+
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		<-unblock
+		fmt.Fprint(w, `{"errorcode":0}`)
+	}))
+	defer srv.Close()
+	defer close(unblock)
+	ct := &countingTransport{}
+	y = Init(as,
+		WithHost(strings.TrimPrefix(srv.URL, "http://")),
+		WithHTTPClient(&http.Client{Transport: ct}),
+		WithTimeout(20*time.Millisecond))
+	start := time.Now()
+	if _, err := y.GetGroupIDs(); err == nil {
+		t.Errorf("GetGroupIDs succeeded, want timeout\n")
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("WithTimeout not applied, request took %s\n", d)
+	}
+	if ct.n != 1 {
+		t.Errorf("custom client saw %d requests, want 1\n", ct.n)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var target, auth string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		target, auth = r.URL.Host, r.Header.Get("Proxy-Authorization")
+		fmt.Fprint(w, `{"errorcode":0}`)
+	}))
+	defer proxy.Close()
+	u, _ := url.Parse(proxy.URL)
+	u.User = url.UserPassword("user", "pass")
+	y := Init(as, WithHost("api.example.invalid"), WithProxy(u))
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs via proxy failed: %s\n", err)
+	}
+	if target != "api.example.invalid" || auth != "Basic dXNlcjpwYXNz" {
+		t.Errorf("proxy saw host %q, Proxy-Authorization %q\n", target, auth)
+	}
+
+	//连接设置与选项的顺序无关
+	target = ""
+	y = Init(as, WithHost("api.example.invalid"), WithProxy(u), WithHTTPClient(&http.Client{}))
+	if _, err := y.GetGroupIDs(); err != nil || target != "api.example.invalid" {
+		t.Errorf("proxy before WithHTTPClient: %v, proxy saw host %q\n", err, target)
+	}
+
+	//自定义RoundTripper无法应用代理, 请求返回ErrTransportOptions而不是绕过代理发送
+	ct := &countingTransport{}
+	y = Init(as, WithProxy(u), WithHTTPClient(&http.Client{Transport: ct}))
+	if _, err := y.GetGroupIDs(); err != ErrTransportOptions {
+		t.Errorf("WithProxy with a custom RoundTripper: %v, want ErrTransportOptions\n", err)
+	}
+	if ct.n != 0 {
+		t.Errorf("custom RoundTripper sent %d requests, want 0\n", ct.n)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	status := http.StatusForbidden
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, "<html>"+strings.Repeat("x", 1000)+"</html>")
+	})
+	defer srv.Close()
+	_, err := y.GetGroupIDs()
+	se, ok := err.(*StatusError)
+	if !ok {
+		t.Errorf("GetGroupIDs: %#v, want *StatusError\n", err)
+		return
+	}
+	if se.Interface != "getgroupids" || !se.Auth() || se.Temporary() || len(se.Body) != 512 || !strings.HasPrefix(se.Body, "<html>") {
+		t.Errorf("403: %+v\n", se)
+	}
+	status = http.StatusBadGateway
+	if _, err = y.GetGroupIDs(); err == nil {
+		t.Errorf("GetGroupIDs succeeded on 502\n")
+	} else if se, ok = err.(*StatusError); !ok || se.Auth() || !se.Temporary() {
+		t.Errorf("502: %#v\n", err)
+	}
+}
+
+func TestWithTransportOptions(t *testing.T) {
+	y := Init(as, WithTransportOptions(TransportOptions{
+		MaxIdleConnsPerHost: 100,
+		IdleConnTimeout:     time.Minute,
+		DisableHTTP2:        true,
+	}))
+	tr, ok := y.client.Transport.(*http.Transport)
+	if !ok {
+		t.Errorf("transport: %T\n", y.client.Transport)
+		return
+	}
+	def := http.DefaultTransport.(*http.Transport)
+	if tr == def || tr.MaxIdleConnsPerHost != 100 || tr.IdleConnTimeout != time.Minute || tr.TLSHandshakeTimeout != def.TLSHandshakeTimeout {
+		t.Errorf("transport not tuned: %+v\n", tr)
+	}
+	if tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Errorf("HTTP/2 not disabled\n")
+	}
+}
+
+func TestStreamDecode(t *testing.T) {
+	ids := make([]string, 10000)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("person%d", i)
+	}
+	data, _ := json.Marshal(GetPersonIDsRsp{PersonIDs: ids})
+	y, srv := newTestYoutu(string(data))
+	defer srv.Close()
+	if y.rawBody("getpersonids") {
+		t.Errorf("plain client needs raw body\n")
+	}
+	gpr, err := y.GetPersonIDs("g")
+	if err != nil || len(gpr.PersonIDs) != len(ids) || gpr.PersonIDs[9999] != "person9999" {
+		t.Errorf("GetPersonIDs: %d ids, %v\n", len(gpr.PersonIDs), err)
+	}
+	y.SetValidationHook(func(string, []Violation) {})
+	if !y.rawBody("getpersonids") {
+		t.Errorf("validation hook without raw body\n")
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	body := `{"errorcode":0,"group_ids":["` + strings.Repeat("g", 1000) + `"]}`
+	chunked := false
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		if !chunked {
+			w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+		}
+		w.Write([]byte(body))
+		w.(http.Flusher).Flush()
+	})
+	defer srv.Close()
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs within default limit failed: %s\n", err)
+	}
+	WithMaxResponseBytes(int64(len(body)))(y)
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs at limit failed: %s\n", err)
+	}
+	WithMaxResponseBytes(100)(y)
+	_, err := y.GetGroupIDs()
+	if te, ok := err.(*ResponseTooLargeError); !ok || te.Interface != "getgroupids" || te.Limit != 100 {
+		t.Errorf("GetGroupIDs over limit: %v\n", err)
+	}
+	chunked = true
+	_, err = y.GetGroupIDs()
+	if _, ok := err.(*ResponseTooLargeError); !ok {
+		t.Errorf("chunked GetGroupIDs over limit: %v\n", err)
+	}
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{"errorcode":0}`)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	host := strings.TrimPrefix(srv.URL, "https://")
+
+	y := Init(as, WithHost(host), WithScheme("https"))
+	if _, err := y.GetGroupIDs(); err == nil {
+		t.Errorf("GetGroupIDs with default roots succeeded\n")
+	}
+	cert := srv.TLS.Certificates[0]
+	y = Init(as, WithHost(host), WithScheme("https"), WithTLSConfig(&tls.Config{RootCAs: roots, Certificates: []tls.Certificate{cert}}))
+	if _, err := y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs with custom TLS config failed: %s\n", err)
+	}
+}
+
+func TestWithUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "youtu")
+	if err != nil {
+		t.Errorf("TempDir failed: %s\n", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	sock := filepath.Join(dir, "youtu.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Errorf("Listen failed: %s\n", err)
+		return
+	}
+	var host string
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		host = r.Host
+		fmt.Fprint(w, `{"errorcode":0}`)
+	})}
+	go srv.Serve(l)
+	defer srv.Close()
+	y := Init(as, WithHost("api.example.invalid"), WithUnixSocket(sock))
+	if err = y.Warmup(context.Background()); err != nil {
+		t.Errorf("Warmup failed: %s\n", err)
+	}
+	if _, err = y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs over unix socket failed: %s\n", err)
+	}
+	if host != "api.example.invalid" {
+		t.Errorf("Host: %q, want api.example.invalid\n", host)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	var ua string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ua = r.UserAgent()
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+	if _, err := y.GetGroupIDs(); err != nil || ua != "youtu-go/"+Version {
+		t.Errorf("default User-Agent %q, %v\n", ua, err)
+	}
+	WithUserAgent("myapp/1.2")(y)
+	if _, err := y.GetGroupIDs(); err != nil || ua != "youtu-go/"+Version+" myapp/1.2" {
+		t.Errorf("User-Agent %q, %v\n", ua, err)
+	}
+}
+
+func TestPhaseTimeouts(t *testing.T) {
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		<-unblock
+		fmt.Fprint(w, `{"errorcode":0}`)
+	}))
+	defer srv.Close()
+	defer close(unblock)
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	y := Init(as, WithHost(host), WithTimeout(-1), WithTransportOptions(TransportOptions{ResponseHeaderTimeout: 20 * time.Millisecond}))
+	start := time.Now()
+	if _, err := y.GetGroupIDs(); err == nil || time.Since(start) > time.Second {
+		t.Errorf("response header timeout: %v after %s\n", err, time.Since(start))
+	}
+
+	blocking := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	y = Init(as, WithHost(host), WithTimeout(-1), WithDialer(blocking), WithTransportOptions(TransportOptions{DialTimeout: 20 * time.Millisecond}))
+	start = time.Now()
+	if _, err := y.GetGroupIDs(); err == nil || time.Since(start) > time.Second {
+		t.Errorf("dial timeout: %v after %s\n", err, time.Since(start))
+	}
+}
+
+func TestWithAppSign(t *testing.T) {
+	var appIDs, signs []string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			AppID string `json:"app_id"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		raw, _ := base64.StdEncoding.DecodeString(r.Header.Get("Authorization"))
+		appIDs = append(appIDs, req.AppID)
+		signs = append(signs, string(raw[sha1.Size:]))
+		w.Write([]byte(`{"errorcode":0}`))
+	})
+	defer srv.Close()
+	other := as
+	other.appID, other.secretID = 87654321, "other_secret_id"
+	tenant := y.WithAppSign(other)
+	if tenant.client != y.client {
+		t.Errorf("tenant client does not share the http.Client\n")
+	}
+	for _, c := range []*Youtu{y, tenant, y} {
+		if _, err := c.GetGroupIDs(); err != nil {
+			t.Errorf("GetGroupIDs failed: %s\n", err)
+		}
+	}
+	want := []string{"12345678", "87654321", "12345678"}
+	for i := range want {
+		if i >= len(appIDs) || appIDs[i] != want[i] || !strings.HasPrefix(signs[i], "a="+want[i]+"&") {
+			t.Errorf("request %d: app_id %v, sign %v, want %s\n", i, appIDs, signs, want[i])
+			break
+		}
+	}
+	if !strings.Contains(signs[1], "&k=other_secret_id&") {
+		t.Errorf("tenant signed with %s\n", signs[1])
+	}
+}
+
+func TestNewAppSign(t *testing.T) {
+	future := uint32(time.Now().Add(time.Hour).Unix())
+	tests := []struct {
+		appID     uint32
+		secretID  string
+		secretKey string
+		expired   uint32
+		userID    string
+		want      error
+	}{
+		{12345678, "id", "key", 0, "user", nil},
+		{12345678, "id", "key", future, "", nil},
+		{0, "id", "key", 0, "user", ErrAppIDRequired},
+		{12345678, "", "key", 0, "user", ErrSecretIDRequired},
+		{12345678, "id", "", 0, "user", ErrSecretKeyRequired},
+		{12345678, "id", "key", 3600, "user", ErrExpiredInPast},
+		{12345678, "id", "key", 0, strings.Repeat("u", UserIDMaxLen+1), ErrUserIDTooLong},
+	}
+	for i, tt := range tests {
+		if _, err := NewAppSign(tt.appID, tt.secretID, tt.secretKey, tt.expired, tt.userID); err != tt.want {
+			t.Errorf("case %d: NewAppSign: %v, want %v\n", i, err, tt.want)
+		}
+	}
+}
+
+func TestFaceShape(t *testing.T) {
+	var path string
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		path = r.URL.Path
+		w.Write([]byte(`{"errorcode":0,"image_width":640,"image_height":480,"face_shape":[{"face_profile":[{"x":10,"y":20},{"x":11,"y":25}],"mouth":[{"x":50,"y":60}]}]}`))
+	})
+	defer srv.Close()
+	fsr, err := y.FaceShape("QUJD", DetectModeNormal)
+	if err != nil || path != "/youtu/api/faceshape" {
+		t.Fatalf("FaceShape: %s %v\n", path, err)
+	}
+	if len(fsr.FaceShape) != 1 || len(fsr.FaceShape[0].FaceProfile) != 2 || fsr.FaceShape[0].FaceProfile[1] != (FacePoint{11, 25}) || fsr.FaceShape[0].Mouth[0].Y != 60 {
+		t.Errorf("FaceShape: %+v\n", fsr)
+	}
+}
+
+func TestFaceIdentifyTopN(t *testing.T) {
+	var req map[string]interface{}
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		req = nil
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Write([]byte(`{"errorcode":0,"person_id":"alice","face_id":"f1","confidence":92.5,"candidates":[` +
+			`{"person_id":"alice","face_id":"f1","confidence":92.5,"tag":"staff"},{"person_id":"bob","face_id":"f7","confidence":61}]}`))
+	})
+	defer srv.Close()
+	var violations []Violation
+	y.SetValidationHook(func(_ string, vs []Violation) { violations = vs })
+	fir, err := y.FaceIdentifyTopN("QUJD", "g", 5)
+	if err != nil || req["topn"] != 5.0 {
+		t.Fatalf("FaceIdentifyTopN: topn %v, %v\n", req["topn"], err)
+	}
+	if len(fir.Candidates) != 2 || fir.Candidates[0].Tag != "staff" || fir.Candidates[1].PersonID != "bob" || len(violations) > 0 {
+		t.Errorf("FaceIdentifyTopN: %+v, violations %v\n", fir, violations)
+	}
+	if _, err = y.FaceIdentify("QUJD", "g"); err != nil || req["topn"] != nil {
+		t.Errorf("FaceIdentify sent topn %v, %v\n", req["topn"], err)
+	}
+}
+
+func TestMultiFaceIdentify(t *testing.T) {
+	y, srv := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		if r.URL.Path != "/youtu/api/multifaceidentify" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"errorcode":0,"results":[` +
+			`{"face_rect":{"x":10,"y":20,"width":80,"height":80},"candidates":[{"person_id":"alice","face_id":"f1","confidence":90}],"errorcode":0},` +
+			`{"face_rect":{"x":200,"y":20,"width":60,"height":60},"candidates":[],"errorcode":-1101,"errormsg":"no match"}]}`))
+	})
+	defer srv.Close()
+	mfr, err := y.MultiFaceIdentify("QUJD", "g", 3)
+	if err != nil || len(mfr.Results) != 2 {
+		t.Fatalf("MultiFaceIdentify: %+v, %v\n", mfr, err)
+	}
+	if r := mfr.Results[0]; r.FaceRect != (FaceRect{10, 20, 80, 80}) || r.Candidates[0].PersonID != "alice" || mfr.Results[1].ErrorCode != -1101 {
+		t.Errorf("MultiFaceIdentify results: %+v\n", mfr.Results)
+	}
+}
@@ -0,0 +1,12 @@
+/*
+* File Name:	doc.go
+* Description:  OpenTelemetry追踪
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+//Package youtuotel 以OpenTelemetry记录优图接口调用的span, 并在请求头中传递trace上下文:
+//
+//	y := youtu.Init(as, youtu.WithTracer(youtuotel.NewTracer(nil)),
+//		youtu.WithInterceptors(youtuotel.Interceptor(nil)))
+package youtuotel
@@ -0,0 +1,74 @@
+/*
+* File Name:	tracer.go
+* Description:  基于OpenTelemetry的Tracer
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtuotel
+
+import (
+	"context"
+
+	"github.com/ochapman/youtu"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/ochapman/youtu"
+
+type tracer struct {
+	t trace.Tracer
+}
+
+//NewTracer 返回以OpenTelemetry记录span的youtu.Tracer, 每次调用一个名为youtu.<接口名>的span,
+//如youtu.detectface. tp为nil时使用otel.GetTracerProvider()
+func NewTracer(tp trace.TracerProvider) youtu.Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tracer{t: tp.Tracer(instrumentationName)}
+}
+
+func (t tracer) Start(ctx context.Context, ifname string) (context.Context, youtu.Span) {
+	ctx, s := t.t.Start(ctx, "youtu."+ifname,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("youtu.interface", ifname)))
+	return ctx, span{s: s}
+}
+
+type span struct {
+	s trace.Span
+}
+
+func (s span) End(e youtu.LogEvent) {
+	s.s.SetAttributes(
+		attribute.Int("http.response.status_code", e.StatusCode),
+		attribute.Int("youtu.errorcode", e.ErrorCode),
+		attribute.Int("youtu.retries", e.Retries),
+		attribute.Int64("youtu.latency_ms", e.Duration.Milliseconds()),
+	)
+	if e.Err != nil {
+		s.s.RecordError(e.Err)
+		s.s.SetStatus(codes.Error, e.Err.Error())
+	}
+	s.s.End()
+}
+
+//Interceptor 返回把ctx中的span以p注入请求头(如W3C traceparent)的youtu.Interceptor, 使服务端的span
+//与调用方的trace关联. 与NewTracer同时使用时注入的是youtu.<接口名>的span.
+//p为nil时使用otel.GetTextMapPropagator(), 其默认不注入任何请求头, 需要先调用otel.SetTextMapPropagator
+func Interceptor(p propagation.TextMapPropagator) youtu.Interceptor {
+	if p == nil {
+		p = otel.GetTextMapPropagator()
+	}
+	return func(next youtu.RoundTripFunc) youtu.RoundTripFunc {
+		return func(ctx context.Context, call *youtu.Call) ([]byte, error) {
+			p.Inject(ctx, propagation.HeaderCarrier(call.Header))
+			return next(ctx, call)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+* File Name:	tracer_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package youtuotel
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ochapman/youtu"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	var traceparent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		traceparent = append(traceparent, r.Header.Get("traceparent"))
+		if strings.HasSuffix(r.URL.Path, "/getinfo") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"errorcode":0}`))
+	}))
+	defer srv.Close()
+	as, err := youtu.NewAppSign(12345678, "your_secret_id", "your_secret_key", 0, "your_qq_id")
+	if err != nil {
+		t.Fatalf("NewAppSign failed: %s\n", err)
+	}
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	y := youtu.Init(as, youtu.WithHost(strings.TrimPrefix(srv.URL, "http://")),
+		youtu.WithTracer(NewTracer(tp)), youtu.WithInterceptors(Interceptor(propagation.TraceContext{})))
+	if _, err = y.GetGroupIDs(); err != nil {
+		t.Errorf("GetGroupIDs failed: %s\n", err)
+	}
+	if _, err = y.GetInfo("person"); err == nil {
+		t.Errorf("GetInfo with status 500 did not fail\n")
+	}
+
+	spans := sr.Ended()
+	if len(spans) != 2 || len(traceparent) != 2 {
+		t.Fatalf("got %d spans, %d requests, want 2\n", len(spans), len(traceparent))
+	}
+	for i, name := range []string{"youtu.getgroupids", "youtu.getinfo"} {
+		s := spans[i]
+		if s.Name() != name {
+			t.Errorf("span %d: name %q, want %q\n", i, s.Name(), name)
+		}
+		//服务端收到的traceparent为00-<trace id>-<span id>-01
+		sc := s.SpanContext()
+		want := "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"
+		if traceparent[i] != want {
+			t.Errorf("span %d: traceparent %q, want %q\n", i, traceparent[i], want)
+		}
+	}
+	if spans[0].Status().Code == codes.Error || spans[1].Status().Code != codes.Error {
+		t.Errorf("span status: %v, %v\n", spans[0].Status(), spans[1].Status())
+	}
+	var status attribute.Value
+	for _, kv := range spans[1].Attributes() {
+		if kv.Key == "http.response.status_code" {
+			status = kv.Value
+		}
+	}
+	if status.AsInt64() != http.StatusInternalServerError {
+		t.Errorf("http.response.status_code: %v, want 500\n", status.Emit())
+	}
+}
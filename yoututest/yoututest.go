@@ -0,0 +1,48 @@
+/*
+* File Name:	yoututest.go
+* Description:  测试用的时间和随机数来源
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+//Package yoututest 提供测试用的时间和随机数来源, 固定签名的时间戳和随机数后,
+//Authorization请求头逐字节确定, 可以用于golden测试:
+//
+//	y := youtu.Init(as, yoututest.Deterministic(time.Unix(1500000000, 0), 42))
+package yoututest
+
+import (
+	"time"
+
+	"github.com/ochapman/youtu"
+)
+
+//FixedClock 总是返回同一时间的youtu.Clock
+type FixedClock time.Time
+
+//Now 实现youtu.Clock
+func (c FixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+//FixedRand 总是返回同一随机数的youtu.Rand, 重试的抖动为0
+type FixedRand int32
+
+//Int31 实现youtu.Rand, 作为签名的随机数
+func (r FixedRand) Int31() int32 {
+	return int32(r)
+}
+
+//Int63n 实现youtu.Rand
+func (r FixedRand) Int63n(n int64) int64 {
+	return 0
+}
+
+//Deterministic 固定签名的时间戳为t, 随机数为nonce, 重试的抖动为0.
+//只替换签名使用的时间戳和随机数(youtu.WithFixedSign), 超时、熔断等仍使用客户端的时间来源
+func Deterministic(t time.Time, nonce int32) youtu.Option {
+	return func(y *youtu.Youtu) {
+		youtu.WithFixedSign(t, nonce)(y)
+		youtu.WithRand(FixedRand(nonce))(y)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+* File Name:	yoututest_test.go
+* Description:
+* Author:	Chapman Ou <ochapman.cn@gmail.com>
+* Created:	2026-10-15
+ */
+
+package yoututest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ochapman/youtu"
+)
+
+func TestDeterministic(t *testing.T) {
+	var auth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"errorcode":0}`))
+	}))
+	defer srv.Close()
+	as, err := youtu.NewAppSign(12345678, "your_secret_id", "your_secret_key", 0, "your_qq_id")
+	if err != nil {
+		t.Fatalf("NewAppSign failed: %s\n", err)
+	}
+	y := youtu.Init(as, youtu.WithHost(strings.TrimPrefix(srv.URL, "http://")), Deterministic(time.Unix(1500000000, 0), 42))
+	y.GetGroupIDs()
+	y.GetGroupIDs()
+	//a=12345678&k=your_secret_id&e=0&t=1500000000&r=42&u=your_qq_id&f=
+	const golden = "2YLYeIkWXVyT5ITRlL4jU012CpZhPTEyMzQ1Njc4Jms9eW91cl9zZWNyZXRfaWQmZT0wJnQ9MTUwMDAwMDAwMCZyPTQyJnU9eW91cl9xcV9pZCZmPQ=="
+	if len(auth) != 2 || auth[0] != golden || auth[1] != golden {
+		t.Errorf("Authorization: %q, want %s\n", auth, golden)
+	}
+
+	//签名的时间戳不受客户端的时间来源和时钟偏差影响, 签名有效期从固定的时间戳算起
+	auth = nil
+	y = youtu.Init(as, youtu.WithHost(strings.TrimPrefix(srv.URL, "http://")), youtu.WithClockSkew(time.Hour),
+		youtu.WithSignTTL(time.Minute), Deterministic(time.Unix(1500000000, 0), 42))
+	y.GetGroupIDs()
+	//a=12345678&k=your_secret_id&e=1500000060&t=1500000000&r=42&u=your_qq_id&f=
+	const goldenTTL = "QqnqZYAXRkxa1M0W2O7V7ocrNExhPTEyMzQ1Njc4Jms9eW91cl9zZWNyZXRfaWQmZT0xNTAwMDAwMDYwJnQ9MTUwMDAwMDAwMCZyPTQyJnU9eW91cl9xcV9pZCZmPQ=="
+	if len(auth) != 1 || auth[0] != goldenTTL {
+		t.Errorf("Authorization with TTL: %q, want %s\n", auth, goldenTTL)
+	}
+}